@@ -2,29 +2,43 @@ package cli
 
 import (
 	"errors"
+	"fmt"
+	"os"
 	"strings"
 
 	e "github.com/PossibleLlama/worklog/errors"
 	"github.com/PossibleLlama/worklog/helpers"
 	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 const (
-	configDefaultAuthor   = ""
-	configDefaultDuration = 15
-	configDefaultFormat   = "pretty"
-	configDefaultRepoType = "bolt"
-	configDefaultRepoPath = ""
+	configDefaultAuthor       = ""
+	configDefaultDuration     = 15
+	configDefaultDurationUnit = ""
+	configDefaultFormat       = "pretty"
+	configDefaultTagSeparator = ""
+	configDefaultRepoType     = "bolt"
+	configDefaultRepoPath     = ""
+	configDefaultRepoURL      = ""
+	configDefaultRepoToken    = ""
 )
 
 var (
-	configProvidedAuthor   string
-	configProvidedDuration int
-	configProvidedFormat   string
-	configProvidedRepoType string
-	configProvidedRepoPath string
+	configProvidedAuthor       string
+	configProvidedDuration     int
+	configProvidedDurationUnit string
+	configProvidedFormat       string
+	configProvidedTagSeparator string
+	configProvidedRepoType     string
+	configProvidedRepoPath     string
+	configProvidedRepoURL      string
+	configProvidedRepoToken    string
+
+	configLegacyDurationUnit string
 )
 
 // configureCmd represents the create command
@@ -45,9 +59,23 @@ func ConfigArgs(cmd *cobra.Command, args []string) error {
 func configArgs() error {
 	configProvidedAuthor = configDefaultAuthor
 	configProvidedDuration = configDefaultDuration
+	configProvidedDurationUnit = configDefaultDurationUnit
 	configProvidedFormat = configDefaultFormat
+	configProvidedTagSeparator = configDefaultTagSeparator
 	configProvidedRepoType = configDefaultRepoType
 	configProvidedRepoPath = configDefaultRepoPath
+	configProvidedRepoURL = configDefaultRepoURL
+	configProvidedRepoToken = configDefaultRepoToken
+	return legacyDurationUnitArgs()
+}
+
+// legacyDurationUnitArgs validates the unit of durations recorded
+// before they were stored as minutes
+func legacyDurationUnitArgs() error {
+	configLegacyDurationUnit = strings.ToLower(strings.TrimSpace(configLegacyDurationUnit))
+	if !helpers.ValidLegacyDurationUnit(configLegacyDurationUnit) {
+		return errors.New(e.LegacyDurationUnit)
+	}
 	return nil
 }
 
@@ -59,18 +87,42 @@ func ConfigRun(cmd *cobra.Command, args []string) error {
 func configRun() error {
 	cfg := model.NewConfig(
 		model.Defaults{
-			Author:   configProvidedAuthor,
-			Format:   configProvidedFormat,
-			Duration: configProvidedDuration,
+			Author:       configProvidedAuthor,
+			Format:       configProvidedFormat,
+			Duration:     configProvidedDuration,
+			DurationUnit: configProvidedDurationUnit,
+			TagSeparator: configProvidedTagSeparator,
 		}, model.Repo{
-			Type: configProvidedRepoType,
-			Path: configProvidedRepoPath,
+			Type:  configProvidedRepoType,
+			Path:  configProvidedRepoPath,
+			URL:   configProvidedRepoURL,
+			Token: configProvidedRepoToken,
 		})
+	// Templates, syncing and the server are only set by editing
+	// the file, so are kept as they are
+	templates, err := configTemplates()
+	if err != nil {
+		return err
+	} else if len(templates) > 0 {
+		cfg.Templates = templates
+	}
+	cfg.Sync = model.Sync{
+		URL:   viper.GetString("sync.url"),
+		Token: viper.GetString("sync.token"),
+	}
+	cfg.Server = model.Server{
+		Token:      viper.GetString("server.token"),
+		Persistent: viper.GetBool("server.persistent"),
+	}
 	if err := wlConfig.SaveConfig(cfg); err != nil {
 		return err
 	}
 	helpers.LogInfo("Successfully configured", "configure - saved config")
 
+	// Only used when updating durations from before they were stored in minutes
+	if legacy, ok := wlRepo.(repository.LegacyDurationRepository); ok {
+		legacy.SetLegacyDurationMinutes(helpers.LegacyDurationMinutes(configLegacyDurationUnit))
+	}
 	if err := wlRepo.Init(); err != nil {
 		return err
 	}
@@ -78,6 +130,23 @@ func configRun() error {
 	return nil
 }
 
+// configTemplates the templates in the configuration file. These are
+// read from the file, as viper lowercases the names of templates.
+func configTemplates() (map[string]string, error) {
+	// #nosec G304 -- The configuration file is chosen by the user
+	b, err := os.ReadFile(viper.ConfigFileUsed())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoConfigFileRead, err.Error())
+	}
+	cfg, err := model.ReadConfigYAML(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoConfigFileRead, err.Error())
+	}
+	return cfg.Templates, nil
+}
+
 var overrideDefaultsCmd = &cobra.Command{
 	Use:   "overrideDefaults",
 	Short: "Override the default variables",
@@ -95,13 +164,20 @@ func OverrideDefaultsArgs(cmd *cobra.Command, args []string) error {
 func overrideDefaultsArgs() error {
 	configProvidedAuthor = strings.TrimSpace(configProvidedAuthor)
 	configProvidedFormat = strings.TrimSpace(configProvidedFormat)
+	configProvidedDurationUnit = strings.TrimSpace(configProvidedDurationUnit)
 	configProvidedRepoType = strings.TrimSpace(configProvidedRepoType)
 	configProvidedRepoPath = strings.TrimSpace(configProvidedRepoPath)
+	configProvidedRepoURL = strings.TrimSpace(configProvidedRepoURL)
+	configProvidedRepoToken = strings.TrimSpace(configProvidedRepoToken)
 	if configProvidedAuthor == "" &&
 		configProvidedFormat == "" &&
 		configProvidedDuration < 0 &&
+		configProvidedDurationUnit == "" &&
+		configProvidedTagSeparator == "" &&
 		configProvidedRepoType == "" &&
-		configProvidedRepoPath == "" {
+		configProvidedRepoPath == "" &&
+		configProvidedRepoURL == "" &&
+		configProvidedRepoToken == "" {
 		return errors.New(e.ConfigureArgsMinimum)
 	}
 	if configProvidedDuration < 0 {
@@ -110,14 +186,26 @@ func overrideDefaultsArgs() error {
 	if configProvidedFormat != "" &&
 		configProvidedFormat != "pretty" &&
 		configProvidedFormat != "json" &&
-		configProvidedFormat != "yaml" {
+		configProvidedFormat != "yaml" &&
+		configProvidedFormat != "markdown" &&
+		configProvidedFormat != "md" &&
+		configProvidedFormat != "csv" &&
+		configProvidedFormat != "tsv" {
 		return errors.New(e.Format)
 	}
+	if configProvidedDurationUnit != "" &&
+		configProvidedDurationUnit != helpers.DurationUnitMinutes &&
+		configProvidedDurationUnit != helpers.DurationUnitHours &&
+		configProvidedDurationUnit != helpers.DurationUnitHuman {
+		return errors.New(e.DurationUnit)
+	}
 	if configProvidedRepoType != "" &&
-		configProvidedRepoType != "bolt" &&
-		configProvidedRepoType != "legacy" {
+		!helpers.ValidRepoType(configProvidedRepoType) {
 		return errors.New(e.RootRepoType)
 	}
+	if err := legacyDurationUnitArgs(); err != nil {
+		return err
+	}
 	// Repo path will accept anything, it's up to the user to make sure
 	// the file path makes sense.
 	return nil
@@ -127,6 +215,11 @@ func init() {
 	rootCmd.AddCommand(configureCmd)
 	configureCmd.AddCommand(overrideDefaultsCmd)
 
+	configureCmd.PersistentFlags().StringVar(
+		&configLegacyDurationUnit,
+		"legacyDurationUnit",
+		helpers.DurationUnitMinutes,
+		"Unit existing durations were recorded in, before being stored as minutes. Either 'minutes' or 'hours'")
 	overrideDefaultsCmd.Flags().StringVar(
 		&configProvidedAuthor,
 		"author",
@@ -136,20 +229,40 @@ func init() {
 		&configProvidedDuration,
 		"duration",
 		-1,
-		"Default duration that work takes")
+		"Default duration in minutes that work takes")
+	overrideDefaultsCmd.Flags().StringVar(
+		&configProvidedDurationUnit,
+		"durationUnit",
+		"",
+		"Unit to print durations in. If provided, must be one of 'minutes', 'hours', 'human'")
 	overrideDefaultsCmd.Flags().StringVar(
 		&configProvidedFormat,
 		"format",
 		"",
-		"Format to print work in. If provided, must be one of 'pretty', 'yaml', 'json'")
+		"Format to print work in. If provided, must be one of 'pretty', 'yaml', 'json', 'markdown' (or 'md'), 'csv', 'tsv'")
+	overrideDefaultsCmd.Flags().StringVar(
+		&configProvidedTagSeparator,
+		"tagSeparator",
+		"",
+		"Separator to join tags with when printing as csv or tsv")
 	overrideDefaultsCmd.Flags().StringVar(
 		&configProvidedRepoType,
 		"repo",
 		"bolt",
-		"The type of repository used")
+		"The type of repository used. One of 'bolt', 'legacy', 'sqlite', 'memory', 'jsonl', 'git' or 'remote'")
 	overrideDefaultsCmd.Flags().StringVar(
 		&configProvidedRepoPath,
 		"repoPath",
 		"",
 		"The path to the repository for storage")
+	overrideDefaultsCmd.Flags().StringVar(
+		&configProvidedRepoURL,
+		"repoUrl",
+		"",
+		"The url of the worklog server, for the 'remote' repository")
+	overrideDefaultsCmd.Flags().StringVar(
+		&configProvidedRepoToken,
+		"repoToken",
+		"",
+		"The token to authorise with the worklog server, for the 'remote' repository")
 }
@@ -2,21 +2,30 @@ package cli
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	e "github.com/PossibleLlama/worklog/errors"
 	"github.com/PossibleLlama/worklog/helpers"
 	"github.com/PossibleLlama/worklog/model"
 	"github.com/PossibleLlama/worklog/repository"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func setProvidedConfigureValues(author, format string, duration int, rType, rPath string) {
 	configProvidedAuthor = author
 	configProvidedDuration = duration
+	configProvidedDurationUnit = ""
+	configProvidedTagSeparator = ""
 	configProvidedFormat = format
 	configProvidedRepoType = rType
 	configProvidedRepoPath = rPath
+	configProvidedRepoURL = ""
+	configProvidedRepoToken = ""
 }
 
 func TestConfigArgs(t *testing.T) {
@@ -48,6 +57,85 @@ func TestConfigArgs(t *testing.T) {
 	}
 }
 
+func TestConfigArgsLegacyDurationUnit(t *testing.T) {
+	var tests = []struct {
+		name    string
+		unit    string
+		expUnit string
+		expErr  error
+	}{
+		{
+			name:    "Minutes",
+			unit:    "minutes",
+			expUnit: "minutes",
+		}, {
+			name:    "Padded hours",
+			unit:    " Hours ",
+			expUnit: "hours",
+		}, {
+			name:   "Unknown unit",
+			unit:   "hour",
+			expErr: errors.New(e.LegacyDurationUnit),
+		}, {
+			name:   "Human isn't a legacy unit",
+			unit:   "human",
+			expErr: errors.New(e.LegacyDurationUnit),
+		}, {
+			name:   "Empty unit",
+			unit:   "",
+			expErr: errors.New(e.LegacyDurationUnit),
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			configLegacyDurationUnit = testItem.unit
+			defer func() {
+				configLegacyDurationUnit = helpers.DurationUnitMinutes
+			}()
+
+			assert.Equal(t, testItem.expErr, configArgs())
+			setProvidedConfigureValues("", "", -1, "", "/tmp/foo")
+			assert.Equal(t, testItem.expErr, overrideDefaultsArgs())
+			if testItem.expErr == nil {
+				assert.Equal(t, testItem.expUnit, configLegacyDurationUnit)
+			}
+		})
+	}
+}
+
+// legacyDurationRepo records the minutes durations are converted by
+type legacyDurationRepo struct {
+	*repository.MockRepo
+	minutes int
+}
+
+func (r *legacyDurationRepo) SetLegacyDurationMinutes(minutes int) {
+	r.minutes = minutes
+}
+
+func TestConfigRunLegacyDurationUnit(t *testing.T) {
+	configLegacyDurationUnit = helpers.DurationUnitHours
+	defer func() {
+		configLegacyDurationUnit = helpers.DurationUnitMinutes
+	}()
+
+	mockRepo := new(repository.MockRepo)
+	mockRepo.On("SaveConfig", mock.Anything).Return(nil)
+	mockRepo.On("Init").Return(nil)
+	legacy := &legacyDurationRepo{MockRepo: mockRepo}
+	wlConfig = mockRepo
+	wlRepo = legacy
+
+	setProvidedConfigureValues("", "pretty", shortLength, "bolt", "")
+
+	actualErr := configRun()
+
+	assert.Nil(t, actualErr)
+	assert.Equal(t, 60, legacy.minutes)
+	mockRepo.AssertCalled(t, "Init")
+}
+
 func TestConfigRun(t *testing.T) {
 	var tests = []struct {
 		name     string
@@ -205,6 +293,38 @@ func TestOverrideDefaultsArgs(t *testing.T) {
 			rType:    "",
 			rPath:    "",
 			expErr:   nil,
+		}, {
+			name:     "markdown format",
+			author:   "",
+			duration: -1,
+			format:   "markdown",
+			rType:    "",
+			rPath:    "",
+			expErr:   nil,
+		}, {
+			name:     "md format",
+			author:   "",
+			duration: -1,
+			format:   "md",
+			rType:    "",
+			rPath:    "",
+			expErr:   nil,
+		}, {
+			name:     "csv format",
+			author:   "",
+			duration: -1,
+			format:   "csv",
+			rType:    "",
+			rPath:    "",
+			expErr:   nil,
+		}, {
+			name:     "tsv format",
+			author:   "",
+			duration: -1,
+			format:   "tsv",
+			rType:    "",
+			rPath:    "",
+			expErr:   nil,
 		}, {
 			name:     "bolt repo type",
 			author:   "",
@@ -298,3 +418,136 @@ func TestOverrideDefaultsArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestOverrideDefaultsArgsTagSeparator(t *testing.T) {
+	setProvidedConfigureValues("", "", -1, "", "")
+	configProvidedTagSeparator = " | "
+
+	actualErr := overrideDefaultsArgs()
+
+	assert.Nil(t, actualErr)
+	assert.Equal(t, " | ", configProvidedTagSeparator)
+}
+
+func TestConfigRunKeepsTemplates(t *testing.T) {
+	var tests = []struct {
+		name         string
+		file         string
+		expTemplates map[string]string
+		expErr       string
+	}{
+		{
+			name: "Names keep their case",
+			file: "templates:\n  Short: \"{{.Title}}\"\n  weeklyReport: \"- {{.Title}}\"\n",
+			expTemplates: map[string]string{
+				"Short":        "{{.Title}}",
+				"weeklyReport": "- {{.Title}}",
+			},
+		}, {
+			name:         "No templates",
+			file:         "default:\n  format: pretty\n",
+			expTemplates: nil,
+		}, {
+			name:   "Invalid file",
+			file:   "templates: [",
+			expErr: e.RepoConfigFileRead,
+		},
+	}
+
+	previous := viper.ConfigFileUsed()
+	defer viper.SetConfigFile(previous)
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+			assert.Nil(t, os.WriteFile(path, []byte(testItem.file), 0600))
+			viper.SetConfigFile(path)
+
+			cfg := model.NewConfig(model.Defaults{Format: "pretty", Duration: shortLength}, model.Repo{Type: "bolt"})
+			cfg.Templates = testItem.expTemplates
+
+			mockRepo := new(repository.MockRepo)
+			mockRepo.On("SaveConfig", cfg).Return(nil)
+			mockRepo.On("Init").Return(nil)
+			wlConfig = mockRepo
+			wlRepo = mockRepo
+
+			setProvidedConfigureValues("", "pretty", shortLength, "bolt", "")
+
+			actualErr := configRun()
+
+			if testItem.expErr != "" {
+				assert.ErrorContains(t, actualErr, testItem.expErr)
+				mockRepo.AssertNotCalled(t, "SaveConfig", mock.Anything)
+				return
+			}
+			assert.Nil(t, actualErr)
+			mockRepo.AssertCalled(t, "SaveConfig", cfg)
+		})
+	}
+}
+
+func TestOverrideDefaultsArgsRemote(t *testing.T) {
+	setProvidedConfigureValues("", "", -1, "remote", "")
+	configProvidedRepoURL = " http://localhost:8080 "
+	configProvidedRepoToken = " secret "
+
+	actualErr := overrideDefaultsArgs()
+
+	assert.Nil(t, actualErr)
+	assert.Equal(t, "http://localhost:8080", configProvidedRepoURL)
+	assert.Equal(t, "secret", configProvidedRepoToken)
+}
+
+func TestConfigRunRemote(t *testing.T) {
+	cfg := model.NewConfig(model.Defaults{Format: "pretty", Duration: shortLength}, model.Repo{
+		Type:  "remote",
+		URL:   "http://localhost:8080",
+		Token: "secret",
+	})
+
+	mockRepo := new(repository.MockRepo)
+	mockRepo.On("SaveConfig", cfg).Return(nil)
+	mockRepo.On("Init").Return(nil)
+	wlConfig = mockRepo
+	wlRepo = mockRepo
+
+	setProvidedConfigureValues("", "pretty", shortLength, "remote", "")
+	configProvidedRepoURL = "http://localhost:8080"
+	configProvidedRepoToken = "secret"
+
+	actualErr := configRun()
+
+	assert.Nil(t, actualErr)
+	mockRepo.AssertCalled(t, "SaveConfig", cfg)
+}
+
+func TestConfigRunKeepsSyncAndServer(t *testing.T) {
+	viper.Set("sync.url", "http://localhost:8080")
+	viper.Set("sync.token", "secret")
+	viper.Set("server.token", "secret")
+	viper.Set("server.persistent", true)
+	defer func() {
+		viper.Set("sync.url", "")
+		viper.Set("sync.token", "")
+		viper.Set("server.token", "")
+		viper.Set("server.persistent", false)
+	}()
+
+	cfg := model.NewConfig(model.Defaults{Format: "pretty", Duration: shortLength}, model.Repo{Type: "bolt"})
+	cfg.Sync = model.Sync{URL: "http://localhost:8080", Token: "secret"}
+	cfg.Server = model.Server{Token: "secret", Persistent: true}
+
+	mockRepo := new(repository.MockRepo)
+	mockRepo.On("SaveConfig", cfg).Return(nil)
+	mockRepo.On("Init").Return(nil)
+	wlConfig = mockRepo
+	wlRepo = mockRepo
+
+	setProvidedConfigureValues("", "pretty", shortLength, "bolt", "")
+
+	actualErr := configRun()
+
+	assert.Nil(t, actualErr)
+	mockRepo.AssertCalled(t, "SaveConfig", cfg)
+}
@@ -13,15 +13,16 @@ import (
 )
 
 var (
-	createID          string
-	createTitle       string
-	createDescription string
-	createAuthor      string
-	createWhen        time.Time
-	createWhenString  string
-	createDuration    int
-	createTags        []string
-	createTagsString  string
+	createID             string
+	createTitle          string
+	createDescription    string
+	createAuthor         string
+	createWhen           time.Time
+	createWhenString     string
+	createDuration       int
+	createDurationString string
+	createTags           []string
+	createTagsString     string
 )
 
 // createCmd represents the create command
@@ -52,6 +53,15 @@ func createArgs() error {
 	}
 	createWhen = whenDate
 
+	createDuration = -1
+	if strings.TrimSpace(createDurationString) != "" {
+		duration, err := helpers.ParseDuration(createDurationString)
+		if err != nil {
+			return err
+		}
+		createDuration = duration
+	}
+
 	return nil
 }
 
@@ -98,12 +108,11 @@ func init() {
 		"when",
 		helpers.TimeFormat(time.Now()),
 		"When the work was worked in RFC3339 format")
-	createCmd.Flags().IntVarP(
-		&createDuration,
+	createCmd.Flags().StringVar(
+		&createDurationString,
 		"duration",
 		"",
-		-1,
-		"Length of time spent on the work")
+		"Length of time spent on the work, such as 90m, 1.5h or 1h30m")
 	createCmd.Flags().StringVar(
 		&createTagsString,
 		"tags",
@@ -3,6 +3,7 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -24,6 +25,10 @@ func setProvidedCreateArgValues(title, description, author, when string, duratio
 	createDescription = description
 	createAuthor = author
 	createDuration = duration
+	createDurationString = ""
+	if duration >= 0 {
+		createDurationString = strconv.Itoa(duration)
+	}
 	createTagsString = tags
 	createTags = []string{}
 	createWhenString = when
@@ -244,6 +249,63 @@ func TestCreateArgsWhen(t *testing.T) {
 	})
 }
 
+func TestCreateArgsDuration(t *testing.T) {
+	var tests = []struct {
+		name        string
+		duration    string
+		expDuration int
+		expErr      bool
+	}{
+		{
+			name:        "Not provided",
+			duration:    "",
+			expDuration: -1,
+		}, {
+			name:        "Minutes without unit",
+			duration:    "45",
+			expDuration: 45,
+		}, {
+			name:        "Minutes",
+			duration:    "90m",
+			expDuration: 90,
+		}, {
+			name:        "Decimal hours",
+			duration:    "1.5h",
+			expDuration: 90,
+		}, {
+			name:        "Hours and minutes",
+			duration:    "1h30m",
+			expDuration: 90,
+		}, {
+			name:     "Invalid",
+			duration: "lots",
+			expErr:   true,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			setProvidedCreateArgValues(
+				helpers.RandAlphabeticString(shortLength),
+				"",
+				defaultAuthor,
+				helpers.TimeFormat(time.Now()),
+				defaultDuration,
+				"")
+			createDurationString = testItem.duration
+
+			actualErr := createArgs()
+
+			if testItem.expErr {
+				assert.NotNil(t, actualErr)
+			} else {
+				assert.Nil(t, actualErr)
+				assert.Equal(t, testItem.expDuration, createDuration)
+			}
+		})
+	}
+}
+
 func TestCreateRun(t *testing.T) {
 	now, _ := time.Parse(time.RFC3339, time.Now().Format(time.RFC3339))
 	var tests = []struct {
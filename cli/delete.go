@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteIDs   []string
+	deletePurge bool
+)
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete existing records of work",
+	Long: `Delete existing records of work by ID. By default
+a deleted revision is stored, hiding the work while
+keeping its history. Purging removes every revision.`,
+	Args: DeleteArgs,
+	RunE: DeleteRun,
+}
+
+// DeleteArgs public method to validate arguments
+func DeleteArgs(cmd *cobra.Command, args []string) error {
+	return deleteArgs(args)
+}
+
+func deleteArgs(args []string) error {
+	if len(args) == 0 {
+		return errors.New(e.DeleteID)
+	}
+	deleteIDs = args
+	return nil
+}
+
+// DeleteRun public method to run delete
+func DeleteRun(cmd *cobra.Command, args []string) error {
+	return deleteRun()
+}
+
+func deleteRun() error {
+	for _, id := range deleteIDs {
+		code, err := wlService.DeleteWorklog(id, deletePurge)
+		if err != nil {
+			return err
+		}
+		if code == http.StatusNotFound {
+			helpers.LogInfo(fmt.Sprintf("No work found with id %s", id), "delete - none found")
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(deleteCmd)
+
+	deleteCmd.Flags().BoolVar(
+		&deletePurge,
+		"purge",
+		false,
+		"Permanently remove every revision of the work")
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setProvidedDeleteValues(ids []string, purge bool) {
+	deleteIDs = ids
+	deletePurge = purge
+}
+
+func TestDeleteArgs(t *testing.T) {
+	id := helpers.RandAlphabeticString(shortLength)
+
+	var tests = []struct {
+		name   string
+		ids    []string
+		expErr error
+	}{
+		{
+			name:   "No args throws error",
+			ids:    []string{},
+			expErr: errors.New(e.DeleteID),
+		}, {
+			name:   "Single id",
+			ids:    []string{id},
+			expErr: nil,
+		}, {
+			name:   "Multiple ids",
+			ids:    []string{id, id},
+			expErr: nil,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			setProvidedDeleteValues([]string{}, false)
+
+			retErr := deleteArgs(testItem.ids)
+
+			assert.Equal(t, testItem.expErr, retErr)
+			if testItem.expErr == nil {
+				assert.Equal(t, testItem.ids, deleteIDs)
+			}
+		})
+	}
+}
+
+func TestDeleteRun(t *testing.T) {
+	var tests = []struct {
+		name   string
+		ids    []string
+		purge  bool
+		code   int
+		expErr error
+	}{
+		{
+			name:   "Soft deletes each id",
+			ids:    []string{helpers.RandAlphabeticString(shortLength), helpers.RandAlphabeticString(shortLength)},
+			purge:  false,
+			code:   http.StatusNoContent,
+			expErr: nil,
+		}, {
+			name:   "Purges each id",
+			ids:    []string{helpers.RandAlphabeticString(shortLength)},
+			purge:  true,
+			code:   http.StatusNoContent,
+			expErr: nil,
+		}, {
+			name:   "Not found is not an error",
+			ids:    []string{helpers.RandAlphabeticString(shortLength)},
+			purge:  false,
+			code:   http.StatusNotFound,
+			expErr: nil,
+		}, {
+			name:   "Error passed back",
+			ids:    []string{helpers.RandAlphabeticString(shortLength)},
+			purge:  false,
+			code:   http.StatusInternalServerError,
+			expErr: errors.New(helpers.RandAlphabeticString(shortLength)),
+		},
+	}
+
+	for _, testItem := range tests {
+		mockService := new(service.MockService)
+		for _, id := range testItem.ids {
+			mockService.On("DeleteWorklog", id, testItem.purge).Return(testItem.code, testItem.expErr)
+		}
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			setProvidedDeleteValues(testItem.ids, testItem.purge)
+
+			retErr := deleteRun()
+
+			mockService.AssertExpectations(t)
+			for _, id := range testItem.ids {
+				mockService.AssertCalled(t, "DeleteWorklog", id, testItem.purge)
+			}
+			assert.Equal(t, testItem.expErr, retErr)
+		})
+	}
+}
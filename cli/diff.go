@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffID           string
+	diffFromRevision int
+	diffToRevision   int
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show changes between revisions of a worklog",
+	Long: `Shows each field which changed between two
+revisions of a worklog. Without revisions, compares the
+latest revision with the one before it. With a single
+revision, compares that revision with the latest.`,
+	Args: DiffArgs,
+	RunE: DiffRun,
+}
+
+// DiffArgs public method to validate arguments
+func DiffArgs(cmd *cobra.Command, args []string) error {
+	return diffArgs(args)
+}
+
+func diffArgs(args []string) error {
+	if len(args) < 1 || len(args) > 3 {
+		return errors.New(e.DiffArgs)
+	}
+	diffID = args[0]
+	diffFromRevision = 0
+	diffToRevision = 0
+
+	revisions := []*int{&diffFromRevision, &diffToRevision}
+	for index, arg := range args[1:] {
+		rev, err := strconv.Atoi(arg)
+		if err != nil || rev < 1 {
+			return errors.New(e.DiffRevisionNumber)
+		}
+		*revisions[index] = rev
+	}
+
+	verifySingleFormat(false, false)
+	return nil
+}
+
+// DiffRun public method to run diff
+func DiffRun(cmd *cobra.Command, args []string) error {
+	return diffRun()
+}
+
+func diffRun() error {
+	revisions, code, err := wlService.GetWorklogRevisions(diffID)
+	if err != nil {
+		return err
+	}
+	if code == http.StatusNotFound {
+		helpers.LogInfo(fmt.Sprintf("No work found with id %s", diffID), "diff - none found")
+		return nil
+	}
+
+	to := revisions[len(revisions)-1]
+	from := to
+	if len(revisions) > 1 {
+		from = revisions[len(revisions)-2]
+	}
+	if diffFromRevision != 0 {
+		if from = findRevision(revisions, diffFromRevision); from == nil {
+			return fmt.Errorf("%s %d", e.DiffRevisionNotFound, diffFromRevision)
+		}
+	}
+	if diffToRevision != 0 {
+		if to = findRevision(revisions, diffToRevision); to == nil {
+			return fmt.Errorf("%s %d", e.DiffRevisionNotFound, diffToRevision)
+		}
+	}
+
+	diff := model.NewRevisionDiff(from, to)
+	if printOutputPretty {
+		return diff.WritePrettyText(os.Stdout)
+	} else if printOutputYAML {
+		return diff.WriteYAML(os.Stdout)
+	}
+	return diff.WriteJSON(os.Stdout)
+}
+
+func findRevision(revisions []*model.Work, revision int) *model.Work {
+	for _, wl := range revisions {
+		if wl.Revision == revision {
+			return wl
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	addFormatFlags(diffCmd)
+}
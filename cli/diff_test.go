@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffArgs(t *testing.T) {
+	id := helpers.RandAlphabeticString(shortLength)
+
+	var tests = []struct {
+		name    string
+		args    []string
+		expFrom int
+		expTo   int
+		expErr  error
+	}{
+		{
+			name:   "No args throws error",
+			args:   []string{},
+			expErr: errors.New(e.DiffArgs),
+		}, {
+			name:   "Too many args throws error",
+			args:   []string{id, "1", "2", "3"},
+			expErr: errors.New(e.DiffArgs),
+		}, {
+			name:    "Only ID",
+			args:    []string{id},
+			expFrom: 0,
+			expTo:   0,
+		}, {
+			name:    "ID and from revision",
+			args:    []string{id, "2"},
+			expFrom: 2,
+			expTo:   0,
+		}, {
+			name:    "ID and both revisions",
+			args:    []string{id, "2", "5"},
+			expFrom: 2,
+			expTo:   5,
+		}, {
+			name:   "Revision not a number",
+			args:   []string{id, "abc"},
+			expErr: errors.New(e.DiffRevisionNumber),
+		}, {
+			name:   "Revision below one",
+			args:   []string{id, "0"},
+			expErr: errors.New(e.DiffRevisionNumber),
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			setFormatValues(testDefaultFormat)
+
+			retErr := diffArgs(testItem.args)
+
+			assert.Equal(t, testItem.expErr, retErr)
+			if testItem.expErr == nil {
+				assert.Equal(t, id, diffID)
+				assert.Equal(t, testItem.expFrom, diffFromRevision)
+				assert.Equal(t, testItem.expTo, diffToRevision)
+			}
+		})
+	}
+}
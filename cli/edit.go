@@ -13,15 +13,16 @@ import (
 )
 
 var (
-	editID          string
-	editTitle       string
-	editDescription string
-	editDuration    int
-	editAuthor      string
-	editWhen        time.Time
-	editWhenString  string
-	editTags        []string
-	editTagsString  string
+	editID             string
+	editTitle          string
+	editDescription    string
+	editDuration       int
+	editDurationString string
+	editAuthor         string
+	editWhen           time.Time
+	editWhenString     string
+	editTags           []string
+	editTagsString     string
 )
 
 var editCmd = &cobra.Command{
@@ -60,6 +61,15 @@ func editArgs(args []string) error {
 	}
 	editWhen = whenDate
 
+	editDuration = -1
+	if strings.TrimSpace(editDurationString) != "" {
+		duration, err := helpers.ParseDuration(editDurationString)
+		if err != nil {
+			return err
+		}
+		editDuration = duration
+	}
+
 	return nil
 }
 
@@ -104,12 +114,11 @@ func init() {
 		"when",
 		helpers.TimeFormat(time.Now()),
 		"When the work was worked in RFC3339 format")
-	editCmd.Flags().IntVarP(
-		&editDuration,
+	editCmd.Flags().StringVar(
+		&editDurationString,
 		"duration",
 		"",
-		-1,
-		"Length of time spent on the work")
+		"Length of time spent on the work, such as 90m, 1.5h or 1h30m")
 	editCmd.Flags().StringVar(
 		&editTagsString,
 		"tags",
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"errors"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +19,10 @@ func setProvidedEditValues(title, description string, duration int, author, when
 	editTitle = title
 	editDescription = description
 	editDuration = duration
+	editDurationString = ""
+	if duration >= 0 {
+		editDurationString = strconv.Itoa(duration)
+	}
 	editAuthor = author
 	editWhenString = when
 	editTagsString = tags
@@ -1,17 +1,22 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	e "github.com/PossibleLlama/worklog/errors"
 	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/service"
 	"github.com/spf13/cobra"
 )
 
 var exportPath string
+var exportFormat string
+var exportLatestOnly bool
 var exportDefaultPath = fmt.Sprintf(".worklog%sexport-%s.json",
 	string(filepath.Separator), helpers.TimeFormat(time.Now()))
 
@@ -19,8 +24,10 @@ var exportDefaultPath = fmt.Sprintf(".worklog%sexport-%s.json",
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Exports all worklogs",
-	Long: `Exports all worklogs in the given repository type
-to the given file.`,
+	Long: `Exports worklogs in the given repository type
+to the given file. By default every revision of
+every worklog is exported, which can be narrowed
+with the same dates and filters as print.`,
 	Args: ExportArgs,
 	RunE: ExportRun,
 }
@@ -31,6 +38,17 @@ func ExportArgs(cmd *cobra.Command, _ []string) error {
 }
 
 func exportArgs() error {
+	exportFormat = strings.ToLower(strings.TrimSpace(exportFormat))
+	if !service.ValidExportFormat(exportFormat) {
+		return errors.New(e.ExportFormat)
+	}
+
+	verifyFilters()
+	// Unlike print, dates are optional as everything can be exported
+	if err := verifyOptionalDates(); err != nil {
+		return err
+	}
+
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		helpers.LogError(fmt.Sprintf("Unable to get home directory: %s", err.Error()), "export - startup")
@@ -39,6 +57,9 @@ func exportArgs() error {
 
 	if exportPath == "" {
 		exportPath = exportDefaultPath
+		if exportFormat != service.ExportJSON {
+			exportPath = strings.TrimSuffix(exportPath, ".json") + "." + exportFormat
+		}
 	}
 
 	if !strings.HasPrefix(exportPath, string(filepath.Separator)) {
@@ -53,7 +74,8 @@ func ExportRun(cmd *cobra.Command, args []string) error {
 }
 
 func exportRun() error {
-	_, err := wlService.ExportTo(exportPath)
+	_, err := wlService.ExportTo(exportPath, exportFormat,
+		printStartDate, printEndDate, printFilter(), exportLatestOnly)
 
 	return err
 }
@@ -61,9 +83,25 @@ func exportRun() error {
 func init() {
 	rootCmd.AddCommand(exportCmd)
 
+	// Dates
+	addDateFlags(exportCmd)
+
+	// Filters
+	addFilterFlags(exportCmd)
+
 	exportCmd.Flags().StringVar(
 		&exportPath,
 		"path",
-		exportDefaultPath,
-		"File path to export results to")
+		"",
+		"File path to export results to. Defaults to a timestamped file in the .worklog directory")
+	exportCmd.Flags().StringVar(
+		&exportFormat,
+		"format",
+		service.ExportJSON,
+		"Format to export in, either one of 'json', 'yaml', 'csv', 'ndjson' or 'ics'")
+	exportCmd.Flags().BoolVar(
+		&exportLatestOnly,
+		"latest-only",
+		false,
+		"Only export the latest revision of each worklog, excluding deleted worklogs")
 }
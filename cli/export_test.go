@@ -6,7 +6,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	e "github.com/PossibleLlama/worklog/errors"
 	"github.com/PossibleLlama/worklog/helpers"
 	"github.com/PossibleLlama/worklog/service"
 	"github.com/stretchr/testify/assert"
@@ -14,6 +16,17 @@ import (
 
 func setProvidedExportValues(path string) {
 	exportPath = path
+	exportFormat = "json"
+	exportLatestOnly = false
+	printStartDateString = ""
+	printEndDateString = ""
+	printToday = false
+	printThisWeek = false
+	printFilterTitle = ""
+	printFilterDescription = ""
+	printFilterAuthor = ""
+	printFilterTagsString = ""
+	printFilterTags = nil
 }
 
 func TestExportArgs(t *testing.T) {
@@ -77,7 +90,8 @@ func TestExportRun(t *testing.T) {
 
 	for _, testItem := range tests {
 		mockSvc := new(service.MockService)
-		mockSvc.On("ExportTo", testItem.path).Return(shortLength, testItem.expErr)
+		mockSvc.On("ExportTo", testItem.path, "json", time.Time{}, time.Time{}, printFilter(), false).
+			Return(shortLength, testItem.expErr)
 
 		wlService = mockSvc
 
@@ -89,8 +103,80 @@ func TestExportRun(t *testing.T) {
 			mockSvc.AssertExpectations(t)
 			mockSvc.AssertCalled(t,
 				"ExportTo",
-				testItem.path)
+				testItem.path, "json", time.Time{}, time.Time{}, printFilter(), false)
 			assert.Equal(t, testItem.expErr, actualErr)
 		})
 	}
 }
+
+func TestExportArgsFormatDatesAndFilters(t *testing.T) {
+	homeDir, _ := os.UserHomeDir()
+	exportDefaultPath = ".worklog/export.json"
+
+	var tests = []struct {
+		name     string
+		format   string
+		start    string
+		tags     string
+		expPath  string
+		expStart time.Time
+		expEnd   time.Time
+		expTags  []string
+		expErr   error
+		anyErr   bool
+	}{
+		{
+			name:    "No dates exports everything",
+			format:  "json",
+			expPath: filepath.Join(homeDir, ".worklog", "export.json"),
+		}, {
+			name:     "Default path follows format",
+			format:   " CSV ",
+			start:    "2026-10-01",
+			expPath:  filepath.Join(homeDir, ".worklog", "export.csv"),
+			expStart: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:    "Filters",
+			format:  "ndjson",
+			tags:    "client-a, finance",
+			expPath: filepath.Join(homeDir, ".worklog", "export.ndjson"),
+			expTags: []string{"client-a", "finance"},
+		}, {
+			name:    "Calendar",
+			format:  "ics",
+			expPath: filepath.Join(homeDir, ".worklog", "export.ics"),
+		}, {
+			name:   "Unknown format",
+			format: "xml",
+			expErr: errors.New(e.ExportFormat),
+		}, {
+			name:   "Invalid date",
+			format: "json",
+			start:  "not a date",
+			anyErr: true,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			setProvidedExportValues("")
+			exportFormat = testItem.format
+			printStartDateString = testItem.start
+			printFilterTagsString = testItem.tags
+
+			actualErr := exportArgs()
+
+			if testItem.anyErr {
+				assert.NotNil(t, actualErr)
+				return
+			}
+			assert.Equal(t, testItem.expErr, actualErr)
+			if testItem.expErr == nil {
+				assert.Equal(t, testItem.expPath, exportPath)
+				assert.Equal(t, testItem.expStart, printStartDate)
+				assert.Equal(t, testItem.expEnd, printEndDate)
+				assert.Equal(t, testItem.expTags, printFilterTags)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/cobra"
+)
+
+var historyID string
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Print every revision of a worklog",
+	Long: `Prints every stored revision of a single
+worklog, along with when each revision was created.`,
+	Args: HistoryArgs,
+	RunE: HistoryRun,
+}
+
+// HistoryArgs public method to validate arguments
+func HistoryArgs(cmd *cobra.Command, args []string) error {
+	return historyArgs(args)
+}
+
+func historyArgs(args []string) error {
+	if len(args) != 1 {
+		return errors.New(e.HistoryID)
+	}
+	historyID = args[0]
+	verifySingleFormat(false, true)
+	return nil
+}
+
+// HistoryRun public method to run history
+func HistoryRun(cmd *cobra.Command, args []string) error {
+	return historyRun()
+}
+
+func historyRun() error {
+	revisions, code, err := wlService.GetWorklogRevisions(historyID)
+	if err != nil {
+		return err
+	}
+
+	if code == http.StatusNotFound && !printOutputJSON {
+		helpers.LogInfo(fmt.Sprintf("No work found with id %s", historyID), "history - none found")
+		return nil
+	} else if printOutputPretty {
+		return model.WriteAllWorkToText(os.Stdout, revisions)
+	} else if printOutputYAML {
+		return model.WriteAllWorkToYAML(os.Stdout, revisions)
+	} else if printOutputCSV {
+		return model.WriteAllWorkToCSV(os.Stdout, revisions)
+	} else if printOutputTSV {
+		return model.WriteAllWorkToTSV(os.Stdout, revisions)
+	}
+	return model.WriteAllWorkToJSON(os.Stdout, revisions)
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	addFormatFlags(historyCmd)
+	addDelimitedFormatFlags(historyCmd)
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/spf13/cobra"
+)
+
+var importPath string
+var importStrategy string
+var importDryRun bool
+var importFormat string
+var importMappingPath string
+var importAttendee string
+var importSkipDeclined bool
+var importSkipAllDay bool
+
+// importFormatJSON files created by export
+const importFormatJSON = "json"
+
+// importFormatCSV csv files with a mapping file for their columns
+const importFormatCSV = "csv"
+
+// importFormatICS calendar files, with a worklog per event
+const importFormatICS = "ics"
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Imports worklogs from a file",
+	Long: `Imports worklogs from a file created by export,
+keeping their IDs, revisions and when they were created.
+Alternatively imports the csv export of another time
+tracker, such as Toggl, Clockify or Harvest, or the
+events of a calendar.
+Records which can't be imported are reported, without
+stopping the rest of the import.`,
+	Args: ImportArgs,
+	RunE: ImportRun,
+}
+
+// ImportArgs public method to validate arguments
+func ImportArgs(cmd *cobra.Command, _ []string) error {
+	return importArgs()
+}
+
+func importArgs() error {
+	importPath = strings.TrimSpace(importPath)
+	if importPath == "" {
+		return errors.New(e.ImportPath)
+	}
+	importStrategy = strings.ToLower(strings.TrimSpace(importStrategy))
+	if !service.ValidImportStrategy(importStrategy) {
+		return errors.New(e.ImportStrategy)
+	}
+
+	importFormat = strings.ToLower(strings.TrimSpace(importFormat))
+	switch importFormat {
+	case importFormatJSON, importFormatCSV, importFormatICS,
+		model.ImportFormatToggl, model.ImportFormatClockify, model.ImportFormatHarvest:
+	default:
+		return errors.New(e.ImportType)
+	}
+	importMappingPath = strings.TrimSpace(importMappingPath)
+	if (importFormat == importFormatCSV) != (importMappingPath != "") {
+		return errors.New(e.ImportMappingPath)
+	}
+	importAttendee = strings.TrimSpace(importAttendee)
+	if importFormat != importFormatICS &&
+		(printStartDateString != "" || printEndDateString != "" || printToday || printThisWeek ||
+			importAttendee != "" || importSkipDeclined || importSkipAllDay) {
+		return errors.New(e.ImportICSFlags)
+	}
+	if err := verifyOptionalDates(); err != nil {
+		return err
+	}
+
+	var err error
+	if importPath, err = importHomePath(importPath); err != nil {
+		return err
+	}
+	if importMappingPath != "" {
+		importMappingPath, err = importHomePath(importMappingPath)
+	}
+	return err
+}
+
+// importHomePath resolves relative paths from the home directory
+func importHomePath(path string) (string, error) {
+	if strings.HasPrefix(path, string(filepath.Separator)) {
+		return path, nil
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("Unable to get home directory: %s", err.Error()), "import - startup")
+		return "", fmt.Errorf("unable to get home directory: %s", err.Error())
+	}
+	return fmt.Sprintf("%s%s%s", homeDir, string(filepath.Separator), path), nil
+}
+
+// ImportRun public method to run import
+func ImportRun(cmd *cobra.Command, args []string) error {
+	return importRun()
+}
+
+func importRun() error {
+	var summary *model.ImportSummary
+	var err error
+
+	switch importFormat {
+	case importFormatJSON:
+		summary, _, err = wlService.ImportFrom(importPath, importStrategy, importDryRun)
+	case importFormatCSV:
+		b, readErr := os.ReadFile(importMappingPath)
+		if readErr != nil {
+			return fmt.Errorf("%s. %s", e.ImportMappingFile, readErr.Error())
+		}
+		mapping, readErr := model.ReadImportMappingYAML(b)
+		if readErr != nil {
+			return fmt.Errorf("%s. %s", e.ImportMappingFile, readErr.Error())
+		}
+		summary, _, err = wlService.ImportCSVFrom(importPath, mapping, importStrategy, importDryRun)
+	case importFormatICS:
+		summary, _, err = wlService.ImportICSFrom(importPath, &model.ICSImportOptions{
+			Start:        printStartDate,
+			End:          printEndDate,
+			Attendee:     importAttendee,
+			SkipDeclined: importSkipDeclined,
+			SkipAllDay:   importSkipAllDay,
+		}, importStrategy, importDryRun)
+	default:
+		summary, _, err = wlService.ImportCSVFrom(importPath, model.NewImportMapping(importFormat), importStrategy, importDryRun)
+	}
+	if err != nil {
+		return err
+	}
+	return summary.WritePrettyText(os.Stdout)
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVar(
+		&importPath,
+		"path",
+		"",
+		"File path to import worklogs from")
+	importCmd.Flags().StringVar(
+		&importFormat,
+		"format",
+		importFormatJSON,
+		"Format of the file to import. One of 'json', 'toggl', 'clockify', 'harvest', 'csv' or 'ics'")
+	importCmd.Flags().StringVar(
+		&importMappingPath,
+		"mapping",
+		"",
+		"File path of the yaml mapping of columns to worklogs, for the 'csv' format")
+
+	// Calendars
+	addDateFlags(importCmd)
+	importCmd.Flags().StringVar(
+		&importAttendee,
+		"attendee",
+		"",
+		"Name or email of the attendee whose declined events are skipped. Defaults to the configured author")
+	importCmd.Flags().BoolVar(
+		&importSkipDeclined,
+		"skip-declined",
+		false,
+		"Skip events which the attendee has declined, for the 'ics' format")
+	importCmd.Flags().BoolVar(
+		&importSkipAllDay,
+		"skip-all-day",
+		false,
+		"Skip all day events, for the 'ics' format")
+	importCmd.Flags().StringVar(
+		&importStrategy,
+		"strategy",
+		service.ImportSkip,
+		"How to import revisions of work which already exist. One of 'skip', 'overwrite', 'keep-newest-revision'")
+	importCmd.Flags().BoolVar(
+		&importDryRun,
+		"dry-run",
+		false,
+		"Report what would be imported, without saving anything")
+}
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/cobra"
+)
+
+var importGitRepo string
+var importGitSince time.Time
+var importGitSinceString string
+var importGitAuthor string
+var importGitAcceptAll bool
+
+// importGitInput and importGitOutput are where the review of each
+// worklog is read from and written to
+var importGitInput io.Reader = os.Stdin
+var importGitOutput io.Writer = os.Stdout
+
+// importGitCmd represents the import git command
+var importGitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Generates worklogs from git commits",
+	Long: `Proposes a worklog for each commit in a local git
+repository, to accept, edit or skip.
+Commits which have already been imported are not
+proposed again.`,
+	Args: ImportGitArgs,
+	RunE: ImportGitRun,
+}
+
+// ImportGitArgs public method to validate arguments
+func ImportGitArgs(cmd *cobra.Command, _ []string) error {
+	return importGitArgs()
+}
+
+func importGitArgs() error {
+	importGitSince = time.Time{}
+	if strings.TrimSpace(importGitSinceString) != "" {
+		since, err := helpers.GetStringAsDateTime(importGitSinceString)
+		if err != nil {
+			return err
+		}
+		importGitSince = since
+	}
+	importGitAuthor = strings.TrimSpace(importGitAuthor)
+
+	repo, err := filepath.Abs(strings.TrimSpace(importGitRepo))
+	if err != nil {
+		return err
+	}
+	importGitRepo = repo
+	return nil
+}
+
+// ImportGitRun public method to run import git
+func ImportGitRun(cmd *cobra.Command, args []string) error {
+	return importGitRun()
+}
+
+func importGitRun() error {
+	proposed, _, err := wlService.ProposeGitWorklogs(importGitRepo, importGitSince, importGitAuthor)
+	if err != nil {
+		return err
+	}
+	if len(proposed) == 0 {
+		helpers.LogInfo("No commits found which haven't already been imported", "import git - none found")
+		return nil
+	}
+
+	summary := &model.ImportSummary{}
+	reader := bufio.NewReader(importGitInput)
+	for index, wl := range proposed {
+		if !importGitAcceptAll {
+			if _, err := fmt.Fprintf(importGitOutput, "\n(%d/%d)\n%s\n", index+1, len(proposed), wl.PrettyString()); err != nil {
+				return err
+			}
+			accept, quit := reviewGitWorklog(reader, wl)
+			if quit {
+				summary.Skipped += len(proposed) - index
+				break
+			} else if !accept {
+				summary.Skipped++
+				continue
+			}
+		}
+
+		if _, err := wlService.CreateWorklog(wl); err != nil {
+			summary.AddError(index+1, wl, err)
+			continue
+		}
+		summary.Imported++
+	}
+	return summary.WritePrettyText(importGitOutput)
+}
+
+// reviewGitWorklog asks whether to accept, edit or skip the worklog.
+// Quitting, or there being nothing more to read, skips the remaining
+// worklogs.
+func reviewGitWorklog(reader *bufio.Reader, wl *model.Work) (accept, quit bool) {
+	for {
+		answer, err := promptGit(reader, "Accept, edit, skip or quit? [a/e/s/q]")
+		if err != nil {
+			return false, true
+		}
+		switch strings.ToLower(answer) {
+		case "", "a", "accept":
+			return true, false
+		case "e", "edit":
+			if err := editGitWorklog(reader, wl); err != nil {
+				return false, true
+			}
+			return true, false
+		case "s", "skip":
+			return false, false
+		case "q", "quit":
+			return false, true
+		}
+	}
+}
+
+// editGitWorklog asks for new values of the worklog, keeping the
+// existing value when none is given
+func editGitWorklog(reader *bufio.Reader, wl *model.Work) error {
+	title, err := promptGit(reader, fmt.Sprintf("Title [%s]", wl.Title))
+	if err != nil {
+		return err
+	} else if title != "" {
+		wl.Title = title
+	}
+
+	description, err := promptGit(reader, fmt.Sprintf("Description [%s]", wl.Description))
+	if err != nil {
+		return err
+	} else if description != "" {
+		wl.Description = description
+	}
+
+	for {
+		rawDuration, err := promptGit(reader, fmt.Sprintf("Duration [%s]",
+			helpers.FormatDuration(wl.Duration, helpers.DurationUnitHuman)))
+		if err != nil {
+			return err
+		} else if rawDuration == "" {
+			break
+		}
+		duration, err := helpers.ParseDuration(rawDuration)
+		if err == nil {
+			wl.Duration = duration
+			break
+		}
+		if _, err := fmt.Fprintln(importGitOutput, err.Error()); err != nil {
+			return err
+		}
+	}
+
+	rawTags, err := promptGit(reader, fmt.Sprintf("Tags [%s]", strings.Join(wl.Tags, ", ")))
+	if err != nil {
+		return err
+	} else if rawTags != "" {
+		wl.Tags = []string{}
+		for _, tag := range strings.Split(rawTags, ",") {
+			if strings.TrimSpace(tag) != "" {
+				wl.Tags = append(wl.Tags, strings.TrimSpace(tag))
+			}
+		}
+	}
+	return nil
+}
+
+// promptGit writes the question, and reads a line as the answer
+func promptGit(reader *bufio.Reader, question string) (string, error) {
+	if _, err := fmt.Fprintf(importGitOutput, "%s: ", question); err != nil {
+		return "", err
+	}
+	answer, err := reader.ReadString('\n')
+	if err != nil && (err != io.EOF || answer == "") {
+		return "", err
+	}
+	return strings.TrimSpace(answer), nil
+}
+
+func init() {
+	importCmd.AddCommand(importGitCmd)
+
+	importGitCmd.Flags().StringVar(
+		&importGitRepo,
+		"repo",
+		".",
+		"Path of the local git repository to read commits from")
+	importGitCmd.Flags().StringVar(
+		&importGitSinceString,
+		"since",
+		"",
+		"Date from which to read commits")
+	importGitCmd.Flags().StringVar(
+		&importGitAuthor,
+		"author",
+		"",
+		"Only read commits by authors matching this pattern")
+	importGitCmd.Flags().BoolVarP(
+		&importGitAcceptAll,
+		"yes",
+		"y",
+		false,
+		"Accept every commit, without reviewing each one")
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImportGitArgs(t *testing.T) {
+	cwd, _ := os.Getwd()
+
+	var tests = []struct {
+		name     string
+		repo     string
+		since    string
+		expRepo  string
+		expSince time.Time
+		expErr   bool
+	}{
+		{
+			name:    "Relative repository",
+			repo:    ".",
+			expRepo: cwd,
+		}, {
+			name:     "Since date",
+			repo:     "/tmp/project",
+			since:    "2026-10-01",
+			expRepo:  "/tmp/project",
+			expSince: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.Local),
+		}, {
+			name:   "Invalid date",
+			repo:   ".",
+			since:  "not a date",
+			expErr: true,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			importGitRepo = testItem.repo
+			importGitSinceString = testItem.since
+
+			retErr := importGitArgs()
+
+			assert.Equal(t, testItem.expErr, retErr != nil)
+			if !testItem.expErr {
+				assert.Equal(t, filepath.Clean(testItem.expRepo), importGitRepo)
+				assert.True(t, testItem.expSince.Equal(importGitSince))
+			}
+		})
+	}
+}
+
+func TestImportGitRun(t *testing.T) {
+	var tests = []struct {
+		name       string
+		input      string
+		acceptAll  bool
+		expCreated []*model.Work
+		expOutput  string
+	}{
+		{
+			name:      "Accept all",
+			acceptAll: true,
+			expCreated: []*model.Work{
+				{Title: "First", Duration: 15, Tags: []string{"project"}},
+				{Title: "Second", Duration: 15, Tags: []string{"project"}},
+			},
+			expOutput: "Imported: 2\nSkipped: 0\nErrors: 0\n",
+		}, {
+			name:  "Accept and skip",
+			input: "a\ns\n",
+			expCreated: []*model.Work{
+				{Title: "First", Duration: 15, Tags: []string{"project"}},
+			},
+			expOutput: "Imported: 1\nSkipped: 1\nErrors: 0\n",
+		}, {
+			name:  "Unknown answer asks again",
+			input: "maybe\n\nq\n",
+			expCreated: []*model.Work{
+				{Title: "First", Duration: 15, Tags: []string{"project"}},
+			},
+			expOutput: "Imported: 1\nSkipped: 1\nErrors: 0\n",
+		}, {
+			name:  "Edit",
+			input: "e\nEdited\nMore detail\nlong\n1h30m\nproject, review\n",
+			expCreated: []*model.Work{
+				{Title: "Edited", Description: "More detail", Duration: 90, Tags: []string{"project", "review"}},
+			},
+			expOutput: "Imported: 1\nSkipped: 1\nErrors: 0\n",
+		}, {
+			name:       "Quit",
+			input:      "q\n",
+			expCreated: []*model.Work{},
+			expOutput:  "Imported: 0\nSkipped: 2\nErrors: 0\n",
+		},
+	}
+
+	for _, testItem := range tests {
+		proposed := []*model.Work{
+			{Title: "First", Duration: 15, Tags: []string{"project"}},
+			{Title: "Second", Duration: 15, Tags: []string{"project"}},
+		}
+		mockService := new(service.MockService)
+		mockService.On("ProposeGitWorklogs", "/tmp/project", time.Time{}, "").
+			Return(proposed, http.StatusOK, nil)
+		mockService.On("CreateWorklog", mock.Anything).Return(http.StatusCreated, nil)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			var output bytes.Buffer
+			importGitInput = strings.NewReader(testItem.input)
+			importGitOutput = &output
+			importGitRepo = "/tmp/project"
+			importGitSince = time.Time{}
+			importGitAuthor = ""
+			importGitAcceptAll = testItem.acceptAll
+
+			retErr := importGitRun()
+
+			assert.Nil(t, retErr)
+			created := []*model.Work{}
+			for _, call := range mockService.Calls {
+				if call.Method == "CreateWorklog" {
+					created = append(created, call.Arguments.Get(0).(*model.Work))
+				}
+			}
+			assert.Equal(t, testItem.expCreated, created)
+			assert.True(t, strings.HasSuffix(output.String(), testItem.expOutput))
+		})
+	}
+}
+
+func TestImportGitRunError(t *testing.T) {
+	expErr := errors.New(helpers.RandAlphabeticString(shortLength))
+	mockService := new(service.MockService)
+	mockService.On("ProposeGitWorklogs", "/tmp/project", time.Time{}, "").
+		Return([]*model.Work{}, http.StatusBadRequest, expErr)
+	wlService = mockService
+
+	importGitRepo = "/tmp/project"
+	importGitSince = time.Time{}
+	importGitAuthor = ""
+
+	assert.Equal(t, expErr, importGitRun())
+}
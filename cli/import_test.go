@@ -0,0 +1,279 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportArgs(t *testing.T) {
+	homeDir, _ := os.UserHomeDir()
+
+	var tests = []struct {
+		name        string
+		path        string
+		strategy    string
+		format      string
+		mapping     string
+		startDate   string
+		endDate     string
+		skipAllDay  bool
+		expPath     string
+		expStrategy string
+		expMapping  string
+		expStart    time.Time
+		expEnd      time.Time
+		expErr      error
+	}{
+		{
+			name:        "Absolute path",
+			path:        "/tmp/import.json",
+			strategy:    "skip",
+			expPath:     "/tmp/import.json",
+			expStrategy: "skip",
+		}, {
+			name:        "Relative path",
+			path:        "import.json",
+			strategy:    " Keep-Newest-Revision ",
+			expPath:     filepath.Join(homeDir, "import.json"),
+			expStrategy: "keep-newest-revision",
+		}, {
+			name:     "No path",
+			path:     " ",
+			strategy: "skip",
+			expErr:   errors.New(e.ImportPath),
+		}, {
+			name:     "Unknown strategy",
+			path:     "/tmp/import.json",
+			strategy: "merge",
+			expErr:   errors.New(e.ImportStrategy),
+		}, {
+			name:        "Known csv format",
+			path:        "/tmp/import.csv",
+			strategy:    "skip",
+			format:      " Toggl ",
+			expPath:     "/tmp/import.csv",
+			expStrategy: "skip",
+		}, {
+			name:        "Csv format with mapping",
+			path:        "/tmp/import.csv",
+			strategy:    "skip",
+			format:      "csv",
+			mapping:     "mapping.yml",
+			expPath:     "/tmp/import.csv",
+			expStrategy: "skip",
+			expMapping:  filepath.Join(homeDir, "mapping.yml"),
+		}, {
+			name:     "Unknown format",
+			path:     "/tmp/import.csv",
+			strategy: "skip",
+			format:   "timesheet",
+			expErr:   errors.New(e.ImportType),
+		}, {
+			name:     "Csv format without mapping",
+			path:     "/tmp/import.csv",
+			strategy: "skip",
+			format:   "csv",
+			expErr:   errors.New(e.ImportMappingPath),
+		}, {
+			name:     "Mapping without csv format",
+			path:     "/tmp/import.csv",
+			strategy: "skip",
+			format:   "harvest",
+			mapping:  "/tmp/mapping.yml",
+			expErr:   errors.New(e.ImportMappingPath),
+		}, {
+			name:        "Calendar format with dates",
+			path:        "/tmp/import.ics",
+			strategy:    "skip",
+			format:      "ics",
+			startDate:   "2026-10-01",
+			endDate:     "2026-10-31",
+			skipAllDay:  true,
+			expPath:     "/tmp/import.ics",
+			expStrategy: "skip",
+			expStart:    time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+			expEnd:      time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+		}, {
+			name:        "Calendar format without dates",
+			path:        "/tmp/import.ics",
+			strategy:    "skip",
+			format:      "ics",
+			expPath:     "/tmp/import.ics",
+			expStrategy: "skip",
+		}, {
+			name:      "Dates without calendar format",
+			path:      "/tmp/import.json",
+			strategy:  "skip",
+			startDate: "2026-10-01",
+			expErr:    errors.New(e.ImportICSFlags),
+		}, {
+			name:       "Skipping events without calendar format",
+			path:       "/tmp/import.csv",
+			strategy:   "skip",
+			format:     "toggl",
+			skipAllDay: true,
+			expErr:     errors.New(e.ImportICSFlags),
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			importPath = testItem.path
+			importStrategy = testItem.strategy
+			importFormat = testItem.format
+			if importFormat == "" {
+				importFormat = "json"
+			}
+			importMappingPath = testItem.mapping
+			printStartDateString = testItem.startDate
+			printEndDateString = testItem.endDate
+			printToday = false
+			printThisWeek = false
+			importAttendee = ""
+			importSkipDeclined = false
+			importSkipAllDay = testItem.skipAllDay
+
+			retErr := importArgs()
+
+			assert.Equal(t, testItem.expErr, retErr)
+			if testItem.expErr == nil {
+				assert.Equal(t, testItem.expPath, importPath)
+				assert.Equal(t, testItem.expStrategy, importStrategy)
+				assert.Equal(t, testItem.expMapping, importMappingPath)
+				assert.True(t, testItem.expStart.Equal(printStartDate))
+				assert.True(t, testItem.expEnd.Equal(printEndDate))
+			}
+		})
+	}
+}
+
+func TestImportRun(t *testing.T) {
+	var tests = []struct {
+		name    string
+		summary *model.ImportSummary
+		code    int
+		expErr  error
+	}{
+		{
+			name:    "Sends to service",
+			summary: &model.ImportSummary{Imported: 1},
+			code:    http.StatusOK,
+		}, {
+			name:    "Error passed back",
+			summary: nil,
+			code:    http.StatusBadRequest,
+			expErr:  errors.New(helpers.RandAlphabeticString(shortLength)),
+		},
+	}
+
+	for _, testItem := range tests {
+		mockService := new(service.MockService)
+		mockService.On("ImportFrom", "/tmp/import.json", "overwrite", true).
+			Return(testItem.summary, testItem.code, testItem.expErr)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			importPath = "/tmp/import.json"
+			importStrategy = "overwrite"
+			importDryRun = true
+			importFormat = "json"
+
+			retErr := importRun()
+
+			mockService.AssertCalled(t, "ImportFrom", "/tmp/import.json", "overwrite", true)
+			assert.Equal(t, testItem.expErr, retErr)
+		})
+	}
+}
+
+func TestImportRunCSV(t *testing.T) {
+	mappingPath := filepath.Join(t.TempDir(), "mapping.yml")
+	assert.Nil(t, os.WriteFile(mappingPath, []byte("title: [Task]\ndate: Day\nduration: Time\n"), 0600))
+
+	var tests = []struct {
+		name       string
+		format     string
+		mapping    string
+		expMapping *model.ImportMapping
+		expErr     bool
+	}{
+		{
+			name:       "Known format",
+			format:     model.ImportFormatHarvest,
+			expMapping: model.NewImportMapping(model.ImportFormatHarvest),
+		}, {
+			name:       "Mapping file",
+			format:     "csv",
+			mapping:    mappingPath,
+			expMapping: &model.ImportMapping{Title: []string{"Task"}, Date: "Day", Duration: "Time"},
+		}, {
+			name:    "Missing mapping file",
+			format:  "csv",
+			mapping: filepath.Join(t.TempDir(), "missing.yml"),
+			expErr:  true,
+		},
+	}
+
+	for _, testItem := range tests {
+		mockService := new(service.MockService)
+		mockService.On("ImportCSVFrom", "/tmp/import.csv", testItem.expMapping, "skip", false).
+			Return(&model.ImportSummary{}, http.StatusOK, nil)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			importPath = "/tmp/import.csv"
+			importStrategy = "skip"
+			importDryRun = false
+			importFormat = testItem.format
+			importMappingPath = testItem.mapping
+
+			retErr := importRun()
+
+			if testItem.expErr {
+				assert.ErrorContains(t, retErr, e.ImportMappingFile)
+				mockService.AssertNotCalled(t, "ImportCSVFrom")
+			} else {
+				assert.Nil(t, retErr)
+				mockService.AssertCalled(t, "ImportCSVFrom", "/tmp/import.csv", testItem.expMapping, "skip", false)
+			}
+		})
+	}
+}
+
+func TestImportRunICS(t *testing.T) {
+	start := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+	expOptions := &model.ICSImportOptions{
+		Start:        start,
+		End:          start.AddDate(0, 1, 0),
+		Attendee:     "alice@example.com",
+		SkipDeclined: true,
+	}
+	mockService := new(service.MockService)
+	mockService.On("ImportICSFrom", "/tmp/import.ics", expOptions, "skip", true).
+		Return(&model.ImportSummary{DryRun: true, Imported: 3}, http.StatusOK, nil)
+	wlService = mockService
+
+	importPath = "/tmp/import.ics"
+	importStrategy = "skip"
+	importDryRun = true
+	importFormat = "ics"
+	printStartDate = expOptions.Start
+	printEndDate = expOptions.End
+	importAttendee = "alice@example.com"
+	importSkipDeclined = true
+	importSkipAllDay = false
+
+	assert.Nil(t, importRun())
+	mockService.AssertCalled(t, "ImportICSFrom", "/tmp/import.ics", expOptions, "skip", true)
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateFromType string
+var migrateFromPath string
+var migrateToType string
+var migrateToPath string
+
+var migrateFrom repository.WorklogRepository
+var migrateTo repository.WorklogRepository
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrates worklogs between types of repository",
+	Long: `Copies every revision of every worklog from one
+type of repository into another, which must be empty.
+Afterwards both repositories are checked to hold the
+same revisions. The repository migrated from is left
+untouched.`,
+	Args: MigrateArgs,
+	RunE: MigrateRun,
+}
+
+// MigrateArgs public method to validate arguments
+func MigrateArgs(cmd *cobra.Command, _ []string) error {
+	return migrateArgs()
+}
+
+func migrateArgs() error {
+	migrateFromType = strings.ToLower(strings.TrimSpace(migrateFromType))
+	migrateToType = strings.ToLower(strings.TrimSpace(migrateToType))
+	migrateFromPath = migratePath(migrateFromType, strings.TrimSpace(migrateFromPath))
+	migrateToPath = migratePath(migrateToType, strings.TrimSpace(migrateToPath))
+
+	if migrateFromType == migrateToType && migrateFromPath == migrateToPath {
+		return errors.New(e.MigrateSameRepo)
+	} else if migrateToType == helpers.RepoTypeRemote {
+		return errors.New(e.MigrateToRemote)
+	}
+
+	var err error
+	if migrateFrom, err = newRepo(migrateFromType, migrateFromPath); err != nil {
+		return err
+	}
+	migrateTo, err = newRepo(migrateToType, migrateToPath)
+	return err
+}
+
+// migratePath resolves where the type of repository is stored, defaulting
+// to where it would be used from
+func migratePath(rType, path string) string {
+	if path == "" && rType == helpers.RepoTypeLegacy {
+		return filepath.Dir(cfgFile)
+	} else if path == "" {
+		return helpers.GetRepoPath(rType, "", homeDir)
+	} else if !strings.HasPrefix(path, string(filepath.Separator)) {
+		return fmt.Sprintf("%s%s%s", homeDir, string(filepath.Separator), path)
+	}
+	return path
+}
+
+// MigrateRun public method to run migrate
+func MigrateRun(cmd *cobra.Command, args []string) error {
+	return migrateRun()
+}
+
+func migrateRun() error {
+	summary, _, err := wlService.MigrateWorklogs(migrateFrom, migrateTo)
+	if err != nil {
+		return err
+	}
+	if err := snapshot(migrateTo); err != nil {
+		return err
+	}
+	summary.From = fmt.Sprintf("%s (%s)", migrateFromType, migrateFromPath)
+	summary.To = fmt.Sprintf("%s (%s)", migrateToType, migrateToPath)
+	return summary.WritePrettyText(os.Stdout)
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVar(
+		&migrateFromType,
+		"from",
+		"legacy",
+		"Type of repository to migrate worklogs from, one of 'bolt', 'legacy', 'sqlite', 'memory', 'jsonl', 'git' or 'remote'")
+	migrateCmd.Flags().StringVar(
+		&migrateFromPath,
+		"fromPath",
+		"",
+		"Path of the repository to migrate worklogs from")
+	migrateCmd.Flags().StringVar(
+		&migrateToType,
+		"to",
+		"bolt",
+		"Type of repository to migrate worklogs to, one of 'bolt', 'legacy', 'sqlite', 'memory', 'jsonl' or 'git'")
+	migrateCmd.Flags().StringVar(
+		&migrateToPath,
+		"toPath",
+		"",
+		"Path of the repository to migrate worklogs to")
+}
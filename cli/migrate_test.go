@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateArgs(t *testing.T) {
+	cfgFile = filepath.Join(homeDir, ".worklog", "config.yml")
+
+	var tests = []struct {
+		name     string
+		fromType string
+		fromPath string
+		toType   string
+		toPath   string
+		expFrom  string
+		expTo    string
+		expErr   error
+	}{
+		{
+			name:     "Default paths",
+			fromType: "legacy",
+			toType:   "bolt",
+			expFrom:  filepath.Join(homeDir, ".worklog"),
+			expTo:    filepath.Join(homeDir, ".worklog", "worklog.db"),
+		}, {
+			name:     "Provided paths",
+			fromType: " Bolt ",
+			fromPath: "/tmp/worklog.db",
+			toType:   "LEGACY",
+			toPath:   "legacy",
+			expFrom:  "/tmp/worklog.db",
+			expTo:    filepath.Join(homeDir, "legacy"),
+		}, {
+			name:     "Default paths by type",
+			fromType: "sqlite",
+			toType:   "memory",
+			expFrom:  filepath.Join(homeDir, ".worklog", "worklog.sqlite"),
+			expTo:    "",
+		}, {
+			name:     "Same repository",
+			fromType: "bolt",
+			fromPath: "/tmp/worklog.db",
+			toType:   "bolt",
+			toPath:   "/tmp/worklog.db",
+			expErr:   errors.New(e.MigrateSameRepo),
+		}, {
+			name:     "To remote repository",
+			fromType: "bolt",
+			toType:   "remote",
+			expErr:   errors.New(e.MigrateToRemote),
+		}, {
+			name:     "Unknown repository type",
+			fromType: "legacy",
+			toType:   "sql",
+			expErr:   errors.New(e.RootRepoType),
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			migrateFromType = testItem.fromType
+			migrateFromPath = testItem.fromPath
+			migrateToType = testItem.toType
+			migrateToPath = testItem.toPath
+
+			retErr := migrateArgs()
+
+			assert.Equal(t, testItem.expErr, retErr)
+			if testItem.expErr == nil {
+				assert.Equal(t, testItem.expFrom, migrateFromPath)
+				assert.Equal(t, testItem.expTo, migrateToPath)
+				assert.NotNil(t, migrateFrom)
+				assert.NotNil(t, migrateTo)
+			}
+		})
+	}
+}
+
+func TestMigrateRun(t *testing.T) {
+	var tests = []struct {
+		name    string
+		summary *model.MigrateSummary
+		code    int
+		expErr  error
+	}{
+		{
+			name:    "Sends to service",
+			summary: &model.MigrateSummary{Worklogs: 2, Revisions: 3, Checksum: "abc"},
+			code:    http.StatusOK,
+			expErr:  nil,
+		}, {
+			name:    "Error passed back",
+			summary: nil,
+			code:    http.StatusConflict,
+			expErr:  errors.New(e.MigrateNotEmpty),
+		},
+	}
+
+	for _, testItem := range tests {
+		migrateFrom = new(repository.MockRepo)
+		migrateTo = new(repository.MockRepo)
+		mockService := new(service.MockService)
+		mockService.On("MigrateWorklogs", migrateFrom, migrateTo).Return(testItem.summary, testItem.code, testItem.expErr)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			migrateFromType = "legacy"
+			migrateFromPath = "/tmp/legacy"
+			migrateToType = "bolt"
+			migrateToPath = "/tmp/worklog.db"
+
+			retErr := migrateRun()
+
+			mockService.AssertCalled(t, "MigrateWorklogs", migrateFrom, migrateTo)
+			assert.Equal(t, testItem.expErr, retErr)
+			if testItem.expErr == nil {
+				assert.Equal(t, "legacy (/tmp/legacy)", testItem.summary.From)
+				assert.Equal(t, "bolt (/tmp/worklog.db)", testItem.summary.To)
+			}
+		})
+	}
+}
@@ -33,6 +33,13 @@ var printFilterTagsString string
 var printOutputPretty bool
 var printOutputYAML bool
 var printOutputJSON bool
+var printOutputCSV bool
+var printOutputTSV bool
+var printOutputMarkdown bool
+
+var printFormat string
+var printTemplate string
+var printTemplateFile string
 
 var printAllFields bool
 
@@ -52,7 +59,10 @@ func PrintArgs(cmd *cobra.Command, args []string) error {
 }
 
 func printArgs(args ...string) error {
-	verifySingleFormat()
+	if err := verifyTemplate(); err != nil {
+		return err
+	}
+	verifySingleFormat(true, true)
 	verifyFilters()
 	return verifyDatesAndIDs(args)
 }
@@ -64,14 +74,7 @@ func PrintRun(cmd *cobra.Command, args []string) error {
 
 func printRun(ids ...string) error {
 	// Passing args through to allow for specifying ID's
-	filter := &model.Work{
-		Title:       printFilterTitle,
-		Description: printFilterDescription,
-		Author:      printFilterAuthor,
-		Duration:    -1,
-		Tags:        printFilterTags,
-		When:        time.Time{},
-		CreatedAt:   time.Time{}}
+	filter := printFilter()
 
 	var worklogs []*model.Work
 	var code int
@@ -95,6 +98,8 @@ func printRun(ids ...string) error {
 			msg = fmt.Sprintf("%s with id's %s", msg, ids)
 		}
 		helpers.LogInfo(msg, "print - none found")
+	} else if printTemplate != "" {
+		printErr = model.WriteAllWorkToTemplate(os.Stdout, worklogs, printTemplate)
 	} else if printOutputPretty {
 		if printAllFields {
 			printErr = model.WriteAllWorkToText(os.Stdout, worklogs)
@@ -107,6 +112,20 @@ func printRun(ids ...string) error {
 		} else {
 			printErr = model.WriteAllWorkToPrettyYAML(os.Stdout, worklogs)
 		}
+	} else if printOutputMarkdown {
+		printErr = model.WriteAllWorkToMarkdown(os.Stdout, worklogs)
+	} else if printOutputCSV {
+		if printAllFields {
+			printErr = model.WriteAllWorkToCSV(os.Stdout, worklogs)
+		} else {
+			printErr = model.WriteAllWorkToPrettyCSV(os.Stdout, worklogs)
+		}
+	} else if printOutputTSV {
+		if printAllFields {
+			printErr = model.WriteAllWorkToTSV(os.Stdout, worklogs)
+		} else {
+			printErr = model.WriteAllWorkToPrettyTSV(os.Stdout, worklogs)
+		}
 	} else {
 		if printAllFields {
 			printErr = model.WriteAllWorkToJSON(os.Stdout, worklogs)
@@ -121,88 +140,209 @@ func init() {
 	rootCmd.AddCommand(printCmd)
 
 	// Dates
+	addDateFlags(printCmd)
+
+	// Filters
+	addFilterFlags(printCmd)
+
+	// Format
+	addFormatFlags(printCmd)
+	addDelimitedFormatFlags(printCmd)
+	addMarkdownFormatFlag(printCmd)
+	printCmd.Flags().StringVar(
+		&printFormat,
+		"format",
+		"",
+		"Output format, either one of 'pretty', 'yaml', 'json', 'markdown', 'csv', 'tsv' or the name of a configured template")
+	printCmd.Flags().StringVar(
+		&printTemplate,
+		"template",
+		"",
+		"Go template to output each worklog with")
 	printCmd.Flags().StringVar(
+		&printTemplateFile,
+		"template-file",
+		"",
+		"Path to a file containing a Go template to output each worklog with")
+
+	// Misc
+	printCmd.Flags().BoolVarP(
+		&printAllFields,
+		"all",
+		"a",
+		false,
+		"Output all fields of the worklog")
+}
+
+// addDateFlags adds the flags selecting the dates of work to a command.
+// Commands sharing these are validated through verifyDates.
+func addDateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
 		&printStartDateString,
 		"startDate",
 		"",
 		"Date from which to find worklogs")
-	printCmd.Flags().StringVar(
+	cmd.Flags().StringVar(
 		&printEndDateString,
 		"endDate",
 		"",
 		"Date till which to find worklogs. Only functions in conjunction with startDate")
-	printCmd.Flags().BoolVarP(
+	cmd.Flags().BoolVarP(
 		&printToday,
 		"today",
 		"t",
 		false,
 		"Print today's work")
-	printCmd.Flags().BoolVarP(
+	cmd.Flags().BoolVarP(
 		&printThisWeek,
 		"thisWeek",
 		"w",
 		false,
 		"Prints this weeks work")
+}
 
-	// Filters
-	printCmd.Flags().StringVar(
+// addFilterFlags adds the flags filtering work to a command.
+// Commands sharing these are validated through verifyFilters.
+func addFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
 		&printFilterTitle,
 		"title",
 		"",
 		"Filter by work including title")
-	printCmd.Flags().StringVar(
+	cmd.Flags().StringVar(
 		&printFilterDescription,
 		"description",
 		"",
 		"Filter by work including description")
-	printCmd.Flags().StringVar(
+	cmd.Flags().StringVar(
 		&printFilterAuthor,
 		"author",
 		"",
 		"Filter by work including author")
-	printCmd.Flags().StringVar(
+	cmd.Flags().StringVar(
 		&printFilterTagsString,
 		"tags",
 		"",
 		"Filter by work including all tags")
+}
 
-	// Format
-	printCmd.Flags().BoolVarP(
+// addFormatFlags adds the flags selecting the output format to a command.
+// Commands sharing these are validated through verifySingleFormat.
+func addFormatFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(
 		&printOutputPretty,
 		"pretty",
 		"p",
 		false,
 		"Output in a text format")
-	printCmd.Flags().BoolVarP(
+	cmd.Flags().BoolVarP(
 		&printOutputYAML,
 		"yaml",
 		"y",
 		false,
 		"Output in a yaml format")
-	printCmd.Flags().BoolVarP(
+	cmd.Flags().BoolVarP(
 		&printOutputJSON,
 		"json",
 		"j",
 		false,
 		"Output in a json format")
+}
 
-	// Misc
-	printCmd.Flags().BoolVarP(
-		&printAllFields,
-		"all",
-		"a",
+// addMarkdownFormatFlag adds the flag selecting a markdown output
+// format to a command.
+// Commands without this flag output json, if configured to use it.
+func addMarkdownFormatFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(
+		&printOutputMarkdown,
+		"markdown",
+		"m",
 		false,
-		"Output all fields of the worklog")
+		"Output in a markdown format")
+}
+
+// addDelimitedFormatFlags adds the flags selecting a delimited output
+// format to a command which outputs a list of work.
+// Commands without these flags output pretty text, if configured to use them.
+func addDelimitedFormatFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&printOutputCSV,
+		"csv",
+		false,
+		"Output in a csv format")
+	cmd.Flags().BoolVar(
+		&printOutputTSV,
+		"tsv",
+		false,
+		"Output in a tsv format")
+}
+
+// verifyTemplate resolves the template to output with, in order of
+// the template, template file then a named format.
+// A named format may also be one of the output formats.
+func verifyTemplate() error {
+	printFormat = strings.TrimSpace(printFormat)
+	if printTemplate == "" && printTemplateFile != "" {
+		b, err := os.ReadFile(printTemplateFile)
+		if err != nil {
+			return fmt.Errorf("%s. %s", e.PrintTemplateFile, err.Error())
+		}
+		printTemplate = strings.TrimRight(string(b), "\n")
+	} else if printTemplate == "" && printFormat != "" {
+		switch strings.ToLower(printFormat) {
+		case "pretty":
+			printOutputPretty = true
+		case "yaml", "yml":
+			printOutputYAML = true
+		case "json":
+			printOutputJSON = true
+		case "markdown", "md":
+			printOutputMarkdown = true
+		case "csv":
+			printOutputCSV = true
+		case "tsv":
+			printOutputTSV = true
+		default:
+			if !viper.IsSet("templates." + printFormat) {
+				return fmt.Errorf("%s: '%s'", e.PrintTemplateName, printFormat)
+			}
+			printTemplate = viper.GetString("templates." + printFormat)
+		}
+	}
+
+	if printTemplate != "" {
+		if _, err := model.ParseWorkTemplate(printTemplate); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // verifySingleFormat ensures that there is only 1 output format used.
-func verifySingleFormat() {
-	if !printOutputPretty && !printOutputYAML && !printOutputJSON {
-		switch viper.GetString("default.format") {
+// Commands without the markdown or delimited format flags output pretty
+// text, if configured to output one of those formats.
+func verifySingleFormat(markdown, delimited bool) {
+	if !printOutputPretty && !printOutputYAML && !printOutputJSON &&
+		!printOutputMarkdown && !printOutputCSV && !printOutputTSV {
+		defaultFormat := viper.GetString("default.format")
+		if !markdown && (defaultFormat == "markdown" || defaultFormat == "md") {
+			helpers.LogWarn(e.FormatMarkdown, "format - markdown unsupported")
+			defaultFormat = "pretty"
+		} else if !delimited && (defaultFormat == "csv" || defaultFormat == "tsv") {
+			helpers.LogWarn(e.FormatDelimited, "format - delimited unsupported")
+			defaultFormat = "pretty"
+		}
+		switch defaultFormat {
 		case "yaml", "yml":
 			printOutputYAML = true
 		case "json":
 			printOutputJSON = true
+		case "markdown", "md":
+			printOutputMarkdown = true
+		case "csv":
+			printOutputCSV = true
+		case "tsv":
+			printOutputTSV = true
 		default:
 			printOutputPretty = true
 		}
@@ -210,12 +350,39 @@ func verifySingleFormat() {
 		if printOutputPretty {
 			printOutputYAML = false
 			printOutputJSON = false
+			printOutputMarkdown = false
+			printOutputCSV = false
+			printOutputTSV = false
 		} else if printOutputYAML {
 			printOutputJSON = false
+			printOutputMarkdown = false
+			printOutputCSV = false
+			printOutputTSV = false
+		} else if printOutputJSON {
+			printOutputMarkdown = false
+			printOutputCSV = false
+			printOutputTSV = false
+		} else if printOutputMarkdown {
+			printOutputCSV = false
+			printOutputTSV = false
+		} else if printOutputCSV {
+			printOutputTSV = false
 		}
 	}
 }
 
+// printFilter the work to match, from the filter flags
+func printFilter() *model.Work {
+	return &model.Work{
+		Title:       printFilterTitle,
+		Description: printFilterDescription,
+		Author:      printFilterAuthor,
+		Duration:    -1,
+		Tags:        printFilterTags,
+		When:        time.Time{},
+		CreatedAt:   time.Time{}}
+}
+
 // verifyFilters ensures that the filters make sense
 func verifyFilters() {
 	printFilterTitle = strings.TrimSpace(printFilterTitle)
@@ -248,6 +415,17 @@ func verifyIDs(ids []string) error {
 	return nil
 }
 
+// verifyOptionalDates ensures the dates are valid when any are
+// provided, otherwise leaving them unset
+func verifyOptionalDates() error {
+	printStartDate = time.Time{}
+	printEndDate = time.Time{}
+	if printStartDateString == "" && !printToday && !printThisWeek {
+		return nil
+	}
+	return verifyDates()
+}
+
 // verifyDates ensures the dates are valid
 func verifyDates() error {
 	if len(printStartDateString) != 0 {
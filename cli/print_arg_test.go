@@ -3,12 +3,15 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/PossibleLlama/worklog/helpers"
 	"github.com/PossibleLlama/worklog/model"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,9 +45,12 @@ var (
 )
 
 type format struct {
-	pretty bool
-	yaml   bool
-	json   bool
+	pretty   bool
+	yaml     bool
+	json     bool
+	markdown bool
+	csv      bool
+	tsv      bool
 }
 
 func setProvidedPrintArgValues(w model.Work, fr format, s, e string, today, week bool) {
@@ -69,6 +75,13 @@ func setFormatValues(fr format) {
 	printOutputPretty = fr.pretty
 	printOutputYAML = fr.yaml
 	printOutputJSON = fr.json
+	printOutputMarkdown = fr.markdown
+	printOutputCSV = fr.csv
+	printOutputTSV = fr.tsv
+
+	printFormat = ""
+	printTemplate = ""
+	printTemplateFile = ""
 }
 
 func TestPrintArgsFormat(t *testing.T) {
@@ -161,6 +174,30 @@ func TestPrintArgsFormat(t *testing.T) {
 				yaml:   true,
 				json:   false,
 			},
+		}, {
+			name:       "Full arguments csv",
+			usedFormat: format{csv: true},
+			expFormat:  format{csv: true},
+		}, {
+			name:       "Full arguments tsv",
+			usedFormat: format{tsv: true},
+			expFormat:  format{tsv: true},
+		}, {
+			name:       "Full arguments markdown",
+			usedFormat: format{markdown: true},
+			expFormat:  format{markdown: true},
+		}, {
+			name:       "Markdown and csv formats",
+			usedFormat: format{markdown: true, csv: true},
+			expFormat:  format{markdown: true},
+		}, {
+			name:       "Json and csv formats",
+			usedFormat: format{json: true, csv: true},
+			expFormat:  format{json: true},
+		}, {
+			name:       "Csv and tsv formats",
+			usedFormat: format{csv: true, tsv: true},
+			expFormat:  format{csv: true},
 		},
 	}
 
@@ -180,8 +217,99 @@ func TestPrintArgsFormat(t *testing.T) {
 			assert.Equal(t, testItem.expFormat.pretty, printOutputPretty)
 			assert.Equal(t, testItem.expFormat.yaml, printOutputYAML)
 			assert.Equal(t, testItem.expFormat.json, printOutputJSON)
+			assert.Equal(t, testItem.expFormat.markdown, printOutputMarkdown)
+			assert.Equal(t, testItem.expFormat.csv, printOutputCSV)
+			assert.Equal(t, testItem.expFormat.tsv, printOutputTSV)
+		})
+	}
+}
+
+func TestPrintArgsDefaultFormat(t *testing.T) {
+	var tests = []struct {
+		name      string
+		config    string
+		markdown  bool
+		delimited bool
+		expFormat format
+	}{
+		{
+			name:      "No config",
+			config:    "",
+			markdown:  true,
+			delimited: true,
+			expFormat: format{pretty: true},
+		}, {
+			name:      "Markdown config",
+			config:    "markdown",
+			markdown:  true,
+			delimited: true,
+			expFormat: format{markdown: true},
+		}, {
+			name:      "Md config",
+			config:    "md",
+			markdown:  true,
+			delimited: true,
+			expFormat: format{markdown: true},
+		}, {
+			name:      "Csv config",
+			config:    "csv",
+			markdown:  true,
+			delimited: true,
+			expFormat: format{csv: true},
+		}, {
+			name:      "Tsv config",
+			config:    "tsv",
+			markdown:  true,
+			delimited: true,
+			expFormat: format{tsv: true},
+		}, {
+			name:      "Csv config without delimited formats",
+			config:    "csv",
+			delimited: false,
+			expFormat: format{pretty: true},
+		}, {
+			name:      "Tsv config without delimited formats",
+			config:    "tsv",
+			delimited: false,
+			expFormat: format{pretty: true},
+		}, {
+			name:      "Markdown config without markdown format",
+			config:    "markdown",
+			markdown:  false,
+			delimited: true,
+			expFormat: format{pretty: true},
+		}, {
+			name:      "Md config without markdown format",
+			config:    "md",
+			markdown:  false,
+			delimited: true,
+			expFormat: format{pretty: true},
+		}, {
+			name:      "Json config without delimited formats",
+			config:    "json",
+			delimited: false,
+			expFormat: format{json: true},
+		},
+	}
+
+	for _, testItem := range tests {
+		setFormatValues(format{})
+		viper.Set("default.format", testItem.config)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			verifySingleFormat(testItem.markdown, testItem.delimited)
+
+			assert.Equal(t, testItem.expFormat, format{
+				pretty:   printOutputPretty,
+				yaml:     printOutputYAML,
+				json:     printOutputJSON,
+				markdown: printOutputMarkdown,
+				csv:      printOutputCSV,
+				tsv:      printOutputTSV,
+			})
 		})
 	}
+	viper.Set("default.format", "")
 }
 
 func TestPrintArgsFilter(t *testing.T) {
@@ -456,3 +584,94 @@ func TestPrintArgsIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintArgsTemplate(t *testing.T) {
+	templateFile := filepath.Join(t.TempDir(), "template.tmpl")
+	assert.Nil(t, os.WriteFile(templateFile, []byte("{{.Title}} from file\n"), 0600))
+	viper.Set("templates.short", "{{.Title}} named")
+	defer viper.Set("templates.short", nil)
+
+	var tests = []struct {
+		name         string
+		format       string
+		template     string
+		templateFile string
+		expTemplate  string
+		expFormat    format
+		expErr       bool
+	}{
+		{
+			name:        "Template",
+			template:    "{{.Title}}",
+			expTemplate: "{{.Title}}",
+			expFormat:   format{pretty: true},
+		}, {
+			name:         "Template file",
+			templateFile: templateFile,
+			expTemplate:  "{{.Title}} from file",
+			expFormat:    format{pretty: true},
+		}, {
+			name:        "Named template",
+			format:      "short",
+			expTemplate: "{{.Title}} named",
+			expFormat:   format{pretty: true},
+		}, {
+			name:         "Template overrides others",
+			format:       "short",
+			template:     "{{.Title}}",
+			templateFile: templateFile,
+			expTemplate:  "{{.Title}}",
+			expFormat:    format{pretty: true},
+		}, {
+			name:      "Named output format",
+			format:    "csv",
+			expFormat: format{csv: true},
+		}, {
+			name:      "Named markdown format",
+			format:    "md",
+			expFormat: format{markdown: true},
+		}, {
+			name:   "Unknown named template",
+			format: "long",
+			expErr: true,
+		}, {
+			name:         "Missing template file",
+			templateFile: filepath.Join(t.TempDir(), "missing.tmpl"),
+			expErr:       true,
+		}, {
+			name:     "Invalid template",
+			template: "{{.Title",
+			expErr:   true,
+		},
+	}
+
+	for _, testItem := range tests {
+		setProvidedPrintArgValues(
+			testDefaultFilter,
+			format{},
+			testDefaultStartDate.Format(time.RFC3339),
+			testDefaultEndDate.Format(time.RFC3339),
+			false,
+			false)
+		printFormat = testItem.format
+		printTemplate = testItem.template
+		printTemplateFile = testItem.templateFile
+
+		t.Run(testItem.name, func(t *testing.T) {
+			err := printArgs()
+
+			assert.Equal(t, testItem.expErr, err != nil)
+			if !testItem.expErr {
+				assert.Equal(t, testItem.expTemplate, printTemplate)
+				assert.Equal(t, testItem.expFormat, format{
+					pretty:   printOutputPretty,
+					yaml:     printOutputYAML,
+					json:     printOutputJSON,
+					markdown: printOutputMarkdown,
+					csv:      printOutputCSV,
+					tsv:      printOutputTSV,
+				})
+			}
+		})
+	}
+}
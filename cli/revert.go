@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	revertID       string
+	revertRevision int
+)
+
+// revertCmd represents the revert command
+var revertCmd = &cobra.Command{
+	Use:   "revert",
+	Short: "Revert a worklog to an earlier revision",
+	Long: `Revert an existing record of work by ID to an
+earlier revision. A new revision is created with the
+contents of the earlier revision, keeping all history.`,
+	Args: RevertArgs,
+	RunE: RevertRun,
+}
+
+// RevertArgs public method to validate arguments
+func RevertArgs(cmd *cobra.Command, args []string) error {
+	return revertArgs(args)
+}
+
+func revertArgs(args []string) error {
+	if len(args) != 1 {
+		return errors.New(e.RevertID)
+	}
+	if revertRevision < 1 {
+		return errors.New(e.RevertRevision)
+	}
+	revertID = args[0]
+	return nil
+}
+
+// RevertRun public method to run revert
+func RevertRun(cmd *cobra.Command, args []string) error {
+	return revertRun()
+}
+
+func revertRun() error {
+	wl, code, err := wlService.RevertWorklog(revertID, revertRevision)
+	if err != nil {
+		return err
+	}
+	if code == http.StatusNotFound {
+		helpers.LogInfo(fmt.Sprintf("No revision %d found for work with id %s", revertRevision, revertID),
+			"revert - none found")
+	} else if wl != nil {
+		helpers.LogInfo(fmt.Sprintf("Reverted %s to revision %d, as revision %d", wl.ID, revertRevision, wl.Revision),
+			"revert - reverted")
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(revertCmd)
+
+	revertCmd.Flags().IntVar(
+		&revertRevision,
+		"to",
+		0,
+		"The earlier revision to revert to")
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevertArgs(t *testing.T) {
+	id := helpers.RandAlphabeticString(shortLength)
+
+	var tests = []struct {
+		name     string
+		ids      []string
+		revision int
+		expErr   error
+	}{
+		{
+			name:     "No args throws error",
+			ids:      []string{},
+			revision: 1,
+			expErr:   errors.New(e.RevertID),
+		}, {
+			name:     "2 ids throws error",
+			ids:      []string{id, id},
+			revision: 1,
+			expErr:   errors.New(e.RevertID),
+		}, {
+			name:     "No revision throws error",
+			ids:      []string{id},
+			revision: 0,
+			expErr:   errors.New(e.RevertRevision),
+		}, {
+			name:     "Valid args",
+			ids:      []string{id},
+			revision: 2,
+			expErr:   nil,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			revertRevision = testItem.revision
+
+			retErr := revertArgs(testItem.ids)
+
+			assert.Equal(t, testItem.expErr, retErr)
+			if testItem.expErr == nil {
+				assert.Equal(t, id, revertID)
+			}
+		})
+	}
+}
+
+func TestRevertRun(t *testing.T) {
+	var tests = []struct {
+		name   string
+		code   int
+		expErr error
+	}{
+		{
+			name:   "Sends to service",
+			code:   http.StatusOK,
+			expErr: nil,
+		}, {
+			name:   "Error passed back",
+			code:   http.StatusInternalServerError,
+			expErr: errors.New(helpers.RandAlphabeticString(shortLength)),
+		},
+	}
+
+	for _, testItem := range tests {
+		id := helpers.RandAlphabeticString(shortLength)
+		mockService := new(service.MockService)
+		mockService.On("RevertWorklog", id, 2).Return(testItem.code, testItem.expErr)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			revertID = id
+			revertRevision = 2
+
+			retErr := revertRun()
+
+			mockService.AssertCalled(t, "RevertWorklog", id, 2)
+			assert.Equal(t, testItem.expErr, retErr)
+		})
+	}
+}
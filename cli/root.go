@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -48,6 +49,16 @@ func Execute() {
 		helpers.LogError(err.Error(), "root")
 		os.Exit(e.StartupErrors)
 	}
+	if err := snapshot(wlRepo); err != nil {
+		helpers.LogError(err.Error(), "root - snapshot")
+		os.Exit(e.RepoErrors)
+	}
+	if wlRepo != nil {
+		if err := wlRepo.Close(); err != nil {
+			helpers.LogError(err.Error(), "root - close")
+			os.Exit(e.RepoErrors)
+		}
+	}
 }
 
 func init() {
@@ -94,17 +105,16 @@ func initConfig() {
 		helpers.LogError(fmt.Sprintf("Unable to use config file: '%s'. %s", viper.ConfigFileUsed(), err.Error()), "root - startup - load config")
 	}
 
-	repoLocation = helpers.GetRepoPath(repoLocation, homeDir)
+	repoType = helpers.GetRepoTypeString(repoType)
+	repoLocation = helpers.GetRepoPath(repoType, repoLocation, homeDir)
+	if repoType == helpers.RepoTypeLegacy {
+		repoLocation = filepath.Dir(cfgFile)
+	}
 
-	switch helpers.GetRepoTypeString(repoType) {
-	case "":
-		fallthrough
-	case "bolt":
-		wlRepo = repository.NewBBoltRepo(repoLocation)
-	case "legacy":
-		wlRepo = repository.NewYamlFileRepo()
-	default:
-		helpers.LogWarn(e.RootRepoType, "root - startup - unknown repo type")
+	var err error
+	wlRepo, err = newRepo(repoType, repoLocation)
+	if err != nil {
+		helpers.LogWarn(err.Error(), "root - startup - unknown repo type")
 		os.Exit(e.StartupErrors)
 	}
 
@@ -112,3 +122,39 @@ func initConfig() {
 		filepath.Dir(cfgFile))
 	wlService = service.NewWorklogService(wlRepo)
 }
+
+// newRepo generates the type of repository, storing worklogs at the path.
+// Bolt, sqlite and jsonl repositories are a file, while legacy and git
+// repositories are a directory. Memory repositories are only written to the file
+// when snapshotted. Remote repositories ignore the path, using the configured
+// url of the server instead.
+func newRepo(rType, path string) (repository.WorklogRepository, error) {
+	switch rType {
+	case "":
+		fallthrough
+	case helpers.RepoTypeBolt:
+		return repository.NewBBoltRepo(path), nil
+	case helpers.RepoTypeLegacy:
+		return repository.NewYamlFileRepo(path), nil
+	case helpers.RepoTypeSQLite:
+		return repository.NewSQLiteRepo(path), nil
+	case helpers.RepoTypeMemory:
+		return repository.NewMemoryRepo(path), nil
+	case helpers.RepoTypeJSONL:
+		return repository.NewJSONLRepo(path), nil
+	case helpers.RepoTypeGit:
+		return repository.NewGitRepo(path), nil
+	case helpers.RepoTypeRemote:
+		return repository.NewRemoteRepo(viper.GetString("repo.url"), viper.GetString("repo.token")), nil
+	}
+	return nil, errors.New(e.RootRepoType)
+}
+
+// snapshot writes out the worklogs of repositories which only hold
+// them in memory
+func snapshot(repo repository.WorklogRepository) error {
+	if snapshots, ok := repo.(repository.SnapshotRepository); ok {
+		return snapshots.Snapshot()
+	}
+	return nil
+}
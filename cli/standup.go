@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/cobra"
+)
+
+// standupCmd represents the standup command
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Print yesterday's and today's work",
+	Long: `Prints the work completed on the previous working
+day, and the work completed today, ready for a stand-up.
+Weekends are skipped, so on a Monday the previous working
+day is Friday.`,
+	Args: StandupArgs,
+	RunE: StandupRun,
+}
+
+// StandupArgs public method to validate arguments
+func StandupArgs(cmd *cobra.Command, args []string) error {
+	return standupArgs()
+}
+
+func standupArgs() error {
+	verifySingleFormat(true, false)
+	verifyFilters()
+	return nil
+}
+
+// StandupRun public method to run standup
+func StandupRun(cmd *cobra.Command, args []string) error {
+	return standupRun()
+}
+
+func standupRun() error {
+	filter := &model.Work{
+		Author: printFilterAuthor,
+		Tags:   printFilterTags,
+	}
+	standup, _, err := wlService.GetStandup(time.Now(), filter)
+	if err != nil {
+		return err
+	}
+
+	if printOutputPretty {
+		return standup.WritePrettyText(os.Stdout)
+	} else if printOutputYAML {
+		return standup.WriteYAML(os.Stdout)
+	} else if printOutputMarkdown {
+		return standup.WriteMarkdown(os.Stdout)
+	}
+	return standup.WriteJSON(os.Stdout)
+}
+
+func init() {
+	rootCmd.AddCommand(standupCmd)
+
+	// Filters
+	standupCmd.Flags().StringVar(
+		&printFilterAuthor,
+		"author",
+		"",
+		"Filter by work including author")
+	standupCmd.Flags().StringVar(
+		&printFilterTagsString,
+		"tags",
+		"",
+		"Filter by work including all tags")
+
+	// Format
+	addFormatFlags(standupCmd)
+	addMarkdownFormatFlag(standupCmd)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStandupArgs(t *testing.T) {
+	setProvidedPrintArgValues(model.Work{
+		Author: " Alice ",
+		Tags:   []string{"a", " b"},
+	}, format{markdown: true}, "", "", false, false)
+
+	retErr := standupArgs()
+
+	assert.Nil(t, retErr)
+	assert.Equal(t, "Alice", printFilterAuthor)
+	assert.Equal(t, []string{"a", "b"}, printFilterTags)
+	assert.True(t, printOutputMarkdown)
+}
+
+func TestStandupRun(t *testing.T) {
+	var tests = []struct {
+		name    string
+		standup *model.Standup
+		code    int
+		expErr  error
+	}{
+		{
+			name:    "Sends to service",
+			standup: model.NewStandup(time.Now(), []*model.Work{}, time.Now(), []*model.Work{}),
+			code:    http.StatusOK,
+		}, {
+			name:    "Error passed back",
+			standup: nil,
+			code:    http.StatusInternalServerError,
+			expErr:  errors.New(helpers.RandAlphabeticString(shortLength)),
+		},
+	}
+
+	for _, testItem := range tests {
+		filter := &model.Work{Author: "Alice", Tags: []string{"a"}}
+		mockService := new(service.MockService)
+		mockService.On("GetStandup", mock.Anything, filter).
+			Return(testItem.standup, testItem.code, testItem.expErr)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			setFormatValues(format{json: true})
+			printFilterAuthor = "Alice"
+			printFilterTags = []string{"a"}
+
+			retErr := standupRun()
+
+			mockService.AssertCalled(t, "GetStandup", mock.Anything, filter)
+			assert.Equal(t, testItem.expErr, retErr)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/cobra"
+)
+
+var summaryGroupByString string
+var summaryGroupBy []string
+
+// summaryCmd represents the summary command
+var summaryCmd = &cobra.Command{
+	Use:   "summary",
+	Short: "Total the worklogs between provided dates",
+	Long: `Totals the count and duration of worklogs
+created between the dates provided. Totals can
+be grouped by day, week, month, tag or author,
+nesting the groups in the order given.`,
+	Args: SummaryArgs,
+	RunE: SummaryRun,
+}
+
+// SummaryArgs public method to validate arguments
+func SummaryArgs(cmd *cobra.Command, args []string) error {
+	return summaryArgs()
+}
+
+func summaryArgs() error {
+	verifySingleFormat(false, false)
+	verifyFilters()
+
+	summaryGroupBy = []string{}
+	for _, group := range strings.Split(summaryGroupByString, ",") {
+		if strings.TrimSpace(group) != "" {
+			summaryGroupBy = append(summaryGroupBy, strings.ToLower(strings.TrimSpace(group)))
+		}
+	}
+	if _, err := model.NewSummary([]*model.Work{}, summaryGroupBy); err != nil {
+		return err
+	}
+	return verifyDates()
+}
+
+// SummaryRun public method to run summary
+func SummaryRun(cmd *cobra.Command, args []string) error {
+	return summaryRun()
+}
+
+func summaryRun() error {
+	summary, code, err := wlService.Summarise(printStartDate, printEndDate, printFilter(), summaryGroupBy)
+	if err != nil {
+		return err
+	}
+
+	if code == http.StatusNotFound && !printOutputJSON {
+		helpers.LogInfo(fmt.Sprintf("No work found between %s and %s with the given filter",
+			printStartDate, printEndDate.Add(time.Second*-1)), "summary - none found")
+		return nil
+	} else if printOutputPretty {
+		return summary.WritePrettyText(os.Stdout)
+	} else if printOutputYAML {
+		return summary.WriteYAML(os.Stdout)
+	}
+	return summary.WriteJSON(os.Stdout)
+}
+
+func init() {
+	rootCmd.AddCommand(summaryCmd)
+
+	// Dates
+	addDateFlags(summaryCmd)
+
+	// Filters
+	addFilterFlags(summaryCmd)
+
+	// Format
+	addFormatFlags(summaryCmd)
+
+	summaryCmd.Flags().StringVar(
+		&summaryGroupByString,
+		"group-by",
+		"",
+		"Comma separated groups to total by, from day, week, month, tag and author")
+}
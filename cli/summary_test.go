@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSummaryArgs(t *testing.T) {
+	var tests = []struct {
+		name       string
+		groupBy    string
+		today      bool
+		expGroupBy []string
+		expErr     bool
+	}{
+		{
+			name:       "No grouping",
+			today:      true,
+			expGroupBy: []string{},
+		}, {
+			name:       "Nested grouping",
+			groupBy:    " Week, tag ,",
+			today:      true,
+			expGroupBy: []string{"week", "tag"},
+		}, {
+			name:    "Unknown grouping",
+			groupBy: "week,year",
+			today:   true,
+			expErr:  true,
+		}, {
+			name:   "No dates",
+			expErr: true,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			setProvidedPrintArgValues(model.Work{}, format{}, "", "", testItem.today, false)
+			summaryGroupByString = testItem.groupBy
+
+			retErr := summaryArgs()
+
+			assert.Equal(t, testItem.expErr, retErr != nil)
+			if !testItem.expErr {
+				assert.Equal(t, testItem.expGroupBy, summaryGroupBy)
+			}
+		})
+	}
+}
+
+func TestSummaryRun(t *testing.T) {
+	var tests = []struct {
+		name    string
+		summary *model.Summary
+		code    int
+		expErr  error
+	}{
+		{
+			name:    "Sends to service",
+			summary: &model.Summary{Count: 1, Duration: 15},
+			code:    http.StatusOK,
+		}, {
+			name:    "None found",
+			summary: &model.Summary{},
+			code:    http.StatusNotFound,
+		}, {
+			name:    "Error passed back",
+			summary: nil,
+			code:    http.StatusInternalServerError,
+			expErr:  errors.New(helpers.RandAlphabeticString(shortLength)),
+		},
+	}
+
+	for _, testItem := range tests {
+		mockService := new(service.MockService)
+		mockService.On("Summarise", mock.Anything, mock.Anything, mock.Anything, []string{"tag"}).
+			Return(testItem.summary, testItem.code, testItem.expErr)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			setFormatValues(format{json: true})
+			summaryGroupBy = []string{"tag"}
+
+			retErr := summaryRun()
+
+			mockService.AssertCalled(t, "Summarise", mock.Anything, mock.Anything, mock.Anything, []string{"tag"})
+			assert.Equal(t, testItem.expErr, retErr)
+		})
+	}
+}
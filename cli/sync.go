@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/spf13/cobra"
+)
+
+var syncRemote string
+var syncStrategy string
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Syncs worklogs with a remote repository",
+	Long: `Pulls worklogs from the remote of the repository,
+merging them with your own, then pushes them back.
+Git repositories sync with their own remote, where worklogs
+changed in both keep the highest revision. Bolt repositories
+sync with a worklog server, where worklogs changed in both
+are kept by the strategy, or left for you to resolve.`,
+	Args: SyncArgs,
+	RunE: SyncRun,
+}
+
+// SyncArgs public method to validate arguments
+func SyncArgs(cmd *cobra.Command, _ []string) error {
+	return syncArgs()
+}
+
+func syncArgs() error {
+	syncRemote = strings.TrimSpace(syncRemote)
+	syncStrategy = strings.ToLower(strings.TrimSpace(syncStrategy))
+	if !service.ValidSyncStrategy(syncStrategy) {
+		return errors.New(e.SyncStrategy)
+	}
+	return nil
+}
+
+// SyncRun public method to run sync
+func SyncRun(cmd *cobra.Command, args []string) error {
+	return syncRun()
+}
+
+func syncRun() error {
+	summary, _, err := wlService.SyncWorklogs(syncRemote, syncStrategy)
+	// Conflicts still sync everything else
+	if summary != nil {
+		if writeErr := summary.WritePrettyText(os.Stdout); writeErr != nil && err == nil {
+			err = writeErr
+		}
+	}
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().StringVar(
+		&syncRemote,
+		"remote",
+		"",
+		"Url of the remote to sync with. Git remotes are remembered for future syncs, while servers default to sync.url from the config")
+	syncCmd.Flags().StringVar(
+		&syncStrategy,
+		"strategy",
+		"",
+		"How worklogs changed both locally and in the server are resolved, one of 'keep-local', 'keep-remote' or 'keep-newest'")
+}
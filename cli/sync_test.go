@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncArgs(t *testing.T) {
+	var tests = []struct {
+		name        string
+		strategy    string
+		expStrategy string
+		expErr      error
+	}{
+		{
+			name:        "Without strategy",
+			strategy:    "",
+			expStrategy: "",
+		}, {
+			name:        "With strategy",
+			strategy:    " Keep-Newest ",
+			expStrategy: service.SyncKeepNewest,
+		}, {
+			name:     "Unknown strategy",
+			strategy: "keep-both",
+			expErr:   errors.New(e.SyncStrategy),
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			syncRemote = "  /tmp/remote.git "
+			syncStrategy = testItem.strategy
+
+			retErr := syncArgs()
+
+			assert.Equal(t, testItem.expErr, retErr)
+			assert.Equal(t, "/tmp/remote.git", syncRemote)
+			if testItem.expErr == nil {
+				assert.Equal(t, testItem.expStrategy, syncStrategy)
+			}
+		})
+	}
+}
+
+func TestSyncRun(t *testing.T) {
+	var tests = []struct {
+		name    string
+		summary *model.SyncSummary
+		code    int
+		expErr  error
+	}{
+		{
+			name:    "Sends to service",
+			summary: &model.SyncSummary{Pulled: 1, Pushed: 2},
+			code:    http.StatusOK,
+			expErr:  nil,
+		}, {
+			name:    "Error passed back",
+			summary: nil,
+			code:    http.StatusNotImplemented,
+			expErr:  errors.New(e.RepoSyncUnsupported),
+		}, {
+			name:    "Conflicts passed back with summary",
+			summary: &model.SyncSummary{Pulled: 1, Conflicts: []string{"abc"}},
+			code:    http.StatusConflict,
+			expErr:  errors.New(e.RepoSyncConflict + ": abc"),
+		},
+	}
+
+	for _, testItem := range tests {
+		mockService := new(service.MockService)
+		mockService.On("SyncWorklogs", "/tmp/remote.git", service.SyncKeepLocal).Return(testItem.summary, testItem.code, testItem.expErr)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			syncRemote = "/tmp/remote.git"
+			syncStrategy = service.SyncKeepLocal
+
+			retErr := syncRun()
+
+			mockService.AssertCalled(t, "SyncWorklogs", "/tmp/remote.git", service.SyncKeepLocal)
+			assert.Equal(t, testItem.expErr, retErr)
+		})
+	}
+}
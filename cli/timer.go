@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	startTitle       string
+	startDescription string
+	startAuthor      string
+	startTags        []string
+	startTagsString  string
+)
+
+// startCmd represents the start command
+var startCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start a timer for new work",
+	Long: `Starts a timer for work that is in progress.
+When stopped, the work is created with the time since
+it was started as the duration.`,
+	Args: StartArgs,
+	RunE: StartRun,
+}
+
+// stopCmd represents the stop command
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running timer",
+	Long: `Stops the running timer, creating a record of
+work with the time since it was started as the duration.`,
+	RunE: StopRun,
+}
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print the running timer",
+	Long:  `Prints the work the running timer is for, and how long it has been running.`,
+	Args:  StatusArgs,
+	RunE:  StatusRun,
+}
+
+// StartArgs public method to validate arguments
+func StartArgs(cmd *cobra.Command, args []string) error {
+	return startArgs()
+}
+
+func startArgs() error {
+	startTags = []string{}
+	for _, tag := range strings.Split(startTagsString, ",") {
+		if strings.TrimSpace(tag) != "" {
+			startTags = append(startTags, strings.TrimSpace(tag))
+		}
+	}
+	return nil
+}
+
+// StartRun public method to run start
+func StartRun(cmd *cobra.Command, args []string) error {
+	return startRun()
+}
+
+func startRun() error {
+	wl := model.NewWork(
+		startTitle,
+		startDescription,
+		startAuthor,
+		0,
+		startTags,
+		time.Time{})
+	_, err := wlService.StartTimer(wl)
+	if err != nil {
+		return err
+	}
+	helpers.LogInfo(fmt.Sprintf("Started timer for '%s'", wl.Title), "start - started")
+	return nil
+}
+
+// StopRun public method to run stop
+func StopRun(cmd *cobra.Command, args []string) error {
+	return stopRun()
+}
+
+func stopRun() error {
+	wl, code, err := wlService.StopTimer()
+	if err != nil {
+		return err
+	}
+	if code == http.StatusNotFound {
+		helpers.LogInfo("No timer is running", "stop - none found")
+		return nil
+	}
+	helpers.LogInfo(fmt.Sprintf("Stopped timer for '%s' with id %s", wl.Title, wl.ID), "stop - stopped")
+	return nil
+}
+
+// StatusArgs public method to validate arguments
+func StatusArgs(cmd *cobra.Command, args []string) error {
+	verifySingleFormat(false, false)
+	return nil
+}
+
+// StatusRun public method to run status
+func StatusRun(cmd *cobra.Command, args []string) error {
+	return statusRun()
+}
+
+func statusRun() error {
+	wl, code, err := wlService.GetTimer()
+	if err != nil {
+		return err
+	}
+
+	if code == http.StatusNotFound && !printOutputJSON {
+		helpers.LogInfo("No timer is running", "status - none found")
+		return nil
+	} else if code == http.StatusNotFound {
+		_, err := os.Stdout.Write([]byte("null"))
+		return err
+	} else if printOutputYAML {
+		return wl.WritePrettyYAML(os.Stdout)
+	} else if printOutputJSON {
+		return wl.WritePrettyJSON(os.Stdout)
+	}
+
+	if err := wl.WritePrettyText(os.Stdout); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(os.Stdout, "\nRunning for: %s\n", time.Since(wl.When).Round(time.Second))
+	return err
+}
+
+func init() {
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(statusCmd)
+
+	startCmd.Flags().StringVar(
+		&startTitle,
+		"title",
+		"",
+		"A short description of the work being done")
+	startCmd.Flags().StringVar(
+		&startDescription,
+		"description",
+		"",
+		"A description of the work")
+	startCmd.Flags().StringVar(
+		&startAuthor,
+		"author",
+		"",
+		"The author of the work")
+	startCmd.Flags().StringVar(
+		&startTagsString,
+		"tags",
+		"",
+		"Comma separated list of tags this work relates to")
+	if err := startCmd.MarkFlagRequired("title"); err != nil {
+		os.Exit(errors.StartupErrors)
+	}
+
+	addFormatFlags(statusCmd)
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestStartArgs(t *testing.T) {
+	var tests = []struct {
+		name    string
+		tags    string
+		expTags []string
+	}{
+		{
+			name:    "No tags",
+			tags:    "",
+			expTags: []string{},
+		}, {
+			name:    "Tags are trimmed",
+			tags:    " a, b ,c",
+			expTags: []string{"a", "b", "c"},
+		}, {
+			name:    "Empty tags are removed",
+			tags:    "a,,  ,b",
+			expTags: []string{"a", "b"},
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			startTagsString = testItem.tags
+
+			retErr := startArgs()
+
+			assert.Nil(t, retErr)
+			assert.Equal(t, testItem.expTags, startTags)
+		})
+	}
+}
+
+func TestStartRun(t *testing.T) {
+	var tests = []struct {
+		name   string
+		expErr error
+	}{
+		{
+			name:   "Sends to service",
+			expErr: nil,
+		}, {
+			name:   "Error passed back",
+			expErr: errors.New(helpers.RandAlphabeticString(shortLength)),
+		},
+	}
+
+	for _, testItem := range tests {
+		title := helpers.RandAlphabeticString(shortLength)
+		mockService := new(service.MockService)
+		mockService.On("StartTimer", mock.MatchedBy(func(wl *model.Work) bool {
+			return wl.Title == title && wl.Duration == 0
+		})).Return(http.StatusCreated, testItem.expErr)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			startTitle = title
+			startTags = []string{}
+
+			retErr := startRun()
+
+			mockService.AssertExpectations(t)
+			assert.Equal(t, testItem.expErr, retErr)
+		})
+	}
+}
+
+func TestStopRun(t *testing.T) {
+	var tests = []struct {
+		name   string
+		wl     *model.Work
+		code   int
+		expErr error
+	}{
+		{
+			name: "Stops timer",
+			wl:   model.NewWork(helpers.RandAlphabeticString(shortLength), "", "", 1, []string{}, now),
+			code: http.StatusCreated,
+		}, {
+			name: "No timer running",
+			wl:   nil,
+			code: http.StatusNotFound,
+		}, {
+			name:   "Error passed back",
+			wl:     nil,
+			code:   http.StatusInternalServerError,
+			expErr: errors.New(helpers.RandAlphabeticString(shortLength)),
+		},
+	}
+
+	for _, testItem := range tests {
+		mockService := new(service.MockService)
+		mockService.On("StopTimer").Return(testItem.wl, testItem.code, testItem.expErr)
+		wlService = mockService
+
+		t.Run(testItem.name, func(t *testing.T) {
+			retErr := stopRun()
+
+			mockService.AssertCalled(t, "StopTimer")
+			assert.Equal(t, testItem.expErr, retErr)
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package errors
 
 // RootRepoType error value for invalid type of repo
-const RootRepoType = "invalid repo, either \"bolt\" or \"legacy\" must be specified"
+const RootRepoType = "invalid repo, one of \"bolt\", \"legacy\", \"sqlite\", \"memory\", \"jsonl\", \"git\" or \"remote\" must be specified"
 
 // ConfigureArgsMinimum error value when not enough args
 const ConfigureArgsMinimum = "overrideDefaults requires at least one argument"
@@ -9,11 +9,77 @@ const ConfigureArgsMinimum = "overrideDefaults requires at least one argument"
 // EditID error value when requires an ID
 const EditID = "edit requires a single ID of an existing worklog"
 
+// DeleteID error value when requires an ID
+const DeleteID = "delete requires at least one ID of an existing worklog"
+
+// HistoryID error value when requires an ID
+const HistoryID = "history requires a single ID of an existing worklog"
+
+// DiffArgs error value when the wrong number of args
+const DiffArgs = "diff requires a single ID, optionally followed by up to two revisions"
+
+// DiffRevisionNumber error value when a revision isn't a number
+const DiffRevisionNumber = "revision must be a number"
+
+// DiffRevisionNotFound error value when a revision doesn't exist
+const DiffRevisionNotFound = "no revision found"
+
+// RevertID error value when requires an ID
+const RevertID = "revert requires a single ID of an existing worklog"
+
+// RevertRevision error value when the revision is invalid
+const RevertRevision = "revert requires a revision of at least 1"
+
+// TimerRunning error value when a timer has already been started
+const TimerRunning = "a timer is already running, stop it before starting another"
+
 // PrintID error value when requires an ID
 const PrintID = "no ids provided"
 
 // PrintArgsMinimum error value when not enough args
 const PrintArgsMinimum = "one flag is required"
 
+// DurationUnit error value when wrong unit for durations
+const DurationUnit = "duration unit is not valid"
+
+// LegacyDurationUnit error value when wrong unit for durations before they were minutes
+const LegacyDurationUnit = "legacy duration unit must be either minutes or hours"
+
 // Format error value when wrong format
 const Format = "format is not valid"
+
+// FormatDelimited warning value when configured to output csv or tsv,
+// by a command which can't
+const FormatDelimited = "only print and history output csv or tsv, so outputting pretty text instead"
+
+// FormatMarkdown warning value when configured to output markdown,
+// by a command which can't
+const FormatMarkdown = "only print and standup output markdown, so outputting pretty text instead"
+
+// PrintTemplateName error value when a named template isn't configured
+const PrintTemplateName = "template is not defined in the configuration"
+
+// PrintTemplateFile error value when a template file can't be read
+const PrintTemplateFile = "unable to read template file"
+
+// ImportPath error value when no file is provided to import
+const ImportPath = "import requires the path of a file"
+
+// MigrateSameRepo error value when migrating a repository into itself
+const MigrateSameRepo = "migrate requires different repositories to migrate from and to"
+
+// MigrateToRemote error value when migrating into a worklog server,
+// which doesn't list deleted worklogs to check they were migrated
+const MigrateToRemote = "migrate can't migrate to a remote repository"
+
+// ImportType error value when the format of the import file is unknown
+const ImportType = "import format must be one of json, toggl, clockify, harvest, csv, ics"
+
+// ImportMappingPath error value when a mapping file is used with the wrong format
+const ImportMappingPath = "a mapping file is required by, and only used with, the csv format"
+
+// ImportICSFlags error value when calendar flags are used with the wrong format
+const ImportICSFlags = "dates, attendee and skipping events are only used with the ics format"
+
+// ImportMappingFile error value when a mapping file can't be read
+const ImportMappingFile = "unable to read mapping file"
@@ -12,12 +12,24 @@ const RepoCreateFile = "unable to create file"
 // RepoConfigFileCreate error value when creating config file
 const RepoConfigFileCreate = "unable to create configuration file"
 
+// RepoConfigFileRead error value when reading config from file
+const RepoConfigFileRead = "unable to read configuration file"
+
 // RepoConfigFileSave error value when saving config to file
 const RepoConfigFileSave = "unable to save config"
 
+// RepoFileName error value when a worklog's file would be outside of the repository
+const RepoFileName = "worklog id can't be used as a file name"
+
 // RepoSaveFile error value when saving file
 const RepoSaveFile = "unable to save worklog"
 
+// RepoDeleteFile error value when deleting file
+const RepoDeleteFile = "unable to delete file"
+
+// RepoDeleteNotFound error value when there is nothing to delete
+const RepoDeleteNotFound = "no worklog found to delete"
+
 // RepoGetFiles error value when getting file
 const RepoGetFiles = "unable to get all files"
 
@@ -27,3 +39,35 @@ const RepoGetFilesRead = "error reading file"
 // RepoGetSingleFileAmbiguous error from having multiple returned
 // values when one is expected
 const RepoGetSingleFileAmbiguous = "must specify a unique ID"
+
+// RepoTimerUnsupported error value when the repository can't store timers
+const RepoTimerUnsupported = "repository type does not support timers"
+
+// RepoSyncUnsupported error value when the repository can't be synced
+const RepoSyncUnsupported = "repository type does not support syncing"
+
+// RepoSyncNoRemote error value when there is no remote to sync with
+const RepoSyncNoRemote = "no remote to sync with"
+
+// RepoSync error value when syncing with the remote fails
+const RepoSync = "unable to sync with remote"
+
+// RepoSyncConflict error value when the same revision of worklogs
+// has changed both locally and in the remote
+const RepoSyncConflict = "worklogs have conflicting changes to the same revision"
+
+// RepoRemoteURL error value when no url is configured for the server
+const RepoRemoteURL = "no url configured for the worklog server, set repo.url"
+
+// RepoRemoteUnreachable error value when the server can't be reached
+const RepoRemoteUnreachable = "unable to reach the worklog server at"
+
+// RepoRemoteUnauthorized error value when the server rejects the token
+const RepoRemoteUnauthorized = "the worklog server rejected the token, check repo.token"
+
+// RepoRemoteResponse error value when the server responds unexpectedly
+const RepoRemoteResponse = "unexpected response from the worklog server"
+
+// RepoRemoteServer error value when the server is configured to store
+// worklogs in another server
+const RepoRemoteServer = "the worklog server can't use a remote repository"
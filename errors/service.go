@@ -0,0 +1,86 @@
+package errors
+
+// ImportStrategy error value when the conflict strategy is unknown
+const ImportStrategy = "import strategy must be one of skip, overwrite, keep-newest-revision"
+
+// ImportRead error value when the import file can't be read
+const ImportRead = "unable to read import file"
+
+// ImportFormat error value when the import file isn't a list of worklogs
+const ImportFormat = "import file must contain a list of worklogs"
+
+// ImportRecordDecode error value when a record isn't a worklog
+const ImportRecordDecode = "unable to decode worklog"
+
+// ImportRecordID error value when a record has no ID
+const ImportRecordID = "worklog requires an id"
+
+// ImportRecordIDInvalid error value when a record's ID isn't only letters and digits
+const ImportRecordIDInvalid = "worklog id must only contain letters and digits"
+
+// ImportRecordRevision error value when a record has an invalid revision
+const ImportRecordRevision = "worklog requires a revision of at least 1"
+
+// ImportRecordTitle error value when a record has no title
+const ImportRecordTitle = "worklog requires a title"
+
+// ImportRecordWhen error value when a record has no time the work was done
+const ImportRecordWhen = "worklog requires when the work was done"
+
+// ImportRecordDuplicate error value when a record is repeated within the import
+const ImportRecordDuplicate = "worklog revision is repeated within the import"
+
+// MigrateNotEmpty error value when the repository being migrated to already has work
+const MigrateNotEmpty = "repository to migrate to must not contain any worklogs"
+
+// MigrateCount error value when the repositories hold a different number of revisions after migrating
+const MigrateCount = "number of revisions migrated does not match"
+
+// MigrateChecksum error value when the repositories hold different revisions after migrating
+const MigrateChecksum = "checksum of revisions migrated does not match"
+
+// ImportMapping error value when a csv mapping is missing required columns
+const ImportMapping = "import mapping requires title, date and duration columns"
+
+// ImportDurationUnit error value when a csv mapping has an unknown duration unit
+const ImportDurationUnit = "import duration unit must be one of minutes, hours, clock"
+
+// ImportCSV error value when the import file isn't a csv file
+const ImportCSV = "import file must be a csv file with a header row"
+
+// ImportCSVColumn error value when the import file doesn't have a mapped column
+const ImportCSVColumn = "import file is missing the column"
+
+// ImportRecordColumns error value when a row doesn't have every column
+const ImportRecordColumns = "row does not have a value for every column"
+
+// ImportRecordDate error value when a row's date can't be parsed
+const ImportRecordDate = "unable to parse when the work was done"
+
+// ImportRecordDuration error value when a row's duration can't be parsed
+const ImportRecordDuration = "unable to parse the duration"
+
+// ImportGitRepo error value when the path isn't a git repository
+const ImportGitRepo = "unable to read git repository"
+
+// ImportGitLog error value when the commits of a git repository can't be read
+const ImportGitLog = "unable to read git history"
+
+// ImportICS error value when the import file isn't an iCalendar file
+const ImportICS = "import file must be an iCalendar file"
+
+// ImportICSUID error value when a calendar event has no UID
+const ImportICSUID = "event requires a uid"
+
+// ImportICSRecurrence error value when a calendar event's recurrence can't be expanded
+const ImportICSRecurrence = "unable to expand the recurrence of the event"
+
+// ExportFormat error value when the export format is unknown
+const ExportFormat = "export format must be one of json, yaml, csv, ndjson, ics"
+
+// SyncStrategy error value when the conflict strategy is unknown
+const SyncStrategy = "sync strategy must be one of keep-local, keep-remote, keep-newest"
+
+// SyncStrategyGit error value when a strategy is used with a git repository,
+// which resolves conflicts itself
+const SyncStrategyGit = "sync strategies are only used when syncing with a worklog server"
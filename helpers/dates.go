@@ -55,3 +55,13 @@ func GetPreviousMonday(originalTime time.Time) time.Time {
 	}
 	return originalTime
 }
+
+// GetPreviousWorkingDay getting midnight of the most recent
+// weekday before the day provided
+func GetPreviousWorkingDay(originalTime time.Time) time.Time {
+	t := Midnight(originalTime).AddDate(0, 0, -1)
+	for t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t
+}
@@ -403,6 +403,42 @@ func TestGetPreviousMonday(t *testing.T) {
 	}
 }
 
+func TestGetPreviousWorkingDay(t *testing.T) {
+	var tests = []struct {
+		name     string
+		input    time.Time
+		expected time.Time
+	}{
+		{
+			name:     "Tuesday gives Monday",
+			input:    initializeTime(t, time.RFC3339, "2000-01-04T12:00:00Z"),
+			expected: initializeTime(t, time.RFC3339, "2000-01-03T00:00:00Z"),
+		}, {
+			name:     "Midnight Tuesday gives Monday",
+			input:    initializeTime(t, time.RFC3339, "2000-01-04T00:00:00Z"),
+			expected: initializeTime(t, time.RFC3339, "2000-01-03T00:00:00Z"),
+		}, {
+			name:     "Monday gives Friday",
+			input:    initializeTime(t, time.RFC3339, "2000-01-03T12:00:00Z"),
+			expected: initializeTime(t, time.RFC3339, "1999-12-31T00:00:00Z"),
+		}, {
+			name:     "Sunday gives Friday",
+			input:    initializeTime(t, time.RFC3339, "2000-01-02T12:00:00Z"),
+			expected: initializeTime(t, time.RFC3339, "1999-12-31T00:00:00Z"),
+		}, {
+			name:     "Saturday gives Friday",
+			input:    initializeTime(t, time.RFC3339, "2000-01-01T12:00:00Z"),
+			expected: initializeTime(t, time.RFC3339, "1999-12-31T00:00:00Z"),
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			assert.Equal(t, testItem.expected, GetPreviousWorkingDay(testItem.input))
+		})
+	}
+}
+
 func BenchmarkGetPreviousMonday(b *testing.B) {
 	var tests = []struct {
 		name  string
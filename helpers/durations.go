@@ -0,0 +1,88 @@
+package helpers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Units durations can be displayed in. Durations are always
+// stored as a number of minutes.
+const (
+	DurationUnitMinutes = "minutes"
+	DurationUnitHours   = "hours"
+	DurationUnitHuman   = "human"
+)
+
+// ParseDuration converts a duration into a number of minutes.
+// Accepts a number of minutes such as "90", or a duration with
+// units such as "90m", "1.5h" or "1h30m".
+func ParseDuration(rawDuration string) (int, error) {
+	duration := strings.ToLower(strings.TrimSpace(rawDuration))
+	if duration == "" {
+		return 0, errors.New("duration must not be empty")
+	}
+
+	var minutes float64
+	if number, err := strconv.ParseFloat(duration, 64); err == nil {
+		minutes = number
+	} else {
+		parsed, err := time.ParseDuration(duration)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s', expected a format such as 90m, 1.5h or 1h30m", rawDuration)
+		}
+		minutes = parsed.Minutes()
+	}
+
+	if minutes < 0 {
+		return 0, fmt.Errorf("invalid duration '%s', must not be negative", rawDuration)
+	}
+	return int(math.Round(minutes)), nil
+}
+
+// FormatDuration displays a number of minutes in the given unit.
+// An unknown unit displays the number of minutes with no unit.
+func FormatDuration(minutes int, unit string) string {
+	switch unit {
+	case DurationUnitMinutes:
+		return fmt.Sprintf("%dm", minutes)
+	case DurationUnitHours:
+		hours := math.Round(float64(minutes)/60*100) / 100
+		return strconv.FormatFloat(hours, 'f', -1, 64) + "h"
+	case DurationUnitHuman:
+		if minutes < 60 {
+			return fmt.Sprintf("%dm", minutes)
+		} else if minutes%60 == 0 {
+			return fmt.Sprintf("%dh", minutes/60)
+		}
+		return fmt.Sprintf("%dh%dm", minutes/60, minutes%60)
+	default:
+		return strconv.Itoa(minutes)
+	}
+}
+
+// GetDurationUnit wrapper for checking viper for the unit
+// durations should be displayed in
+func GetDurationUnit() string {
+	return strings.ToLower(viper.GetString("default.durationUnit"))
+}
+
+// ValidLegacyDurationUnit if durations could have been recorded
+// in the unit, before durations were stored as minutes
+func ValidLegacyDurationUnit(unit string) bool {
+	return unit == DurationUnitMinutes || unit == DurationUnitHours
+}
+
+// LegacyDurationMinutes the number of minutes each unit of a
+// duration was, before durations were stored as minutes
+func LegacyDurationMinutes(unit string) int {
+	if unit == DurationUnitHours {
+		return 60
+	}
+	return 1
+}
@@ -0,0 +1,158 @@
+package helpers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	var tests = []struct {
+		name   string
+		input  string
+		output int
+		err    error
+	}{
+		{
+			name:   "Bare number is minutes",
+			input:  "90",
+			output: 90,
+		}, {
+			name:   "Decimal number is rounded minutes",
+			input:  "90.6",
+			output: 91,
+		}, {
+			name:   "Minutes",
+			input:  "90m",
+			output: 90,
+		}, {
+			name:   "Decimal hours",
+			input:  "1.5h",
+			output: 90,
+		}, {
+			name:   "Hours and minutes",
+			input:  "1h30m",
+			output: 90,
+		}, {
+			name:   "Uppercase and padded",
+			input:  " 2H ",
+			output: 120,
+		}, {
+			name:   "Seconds are rounded",
+			input:  "90s",
+			output: 2,
+		}, {
+			name:  "Empty",
+			input: "",
+			err:   errors.New("duration must not be empty"),
+		}, {
+			name:  "Invalid",
+			input: "an hour",
+			err:   errors.New("invalid duration 'an hour', expected a format such as 90m, 1.5h or 1h30m"),
+		}, {
+			name:  "Negative",
+			input: "-1h",
+			err:   errors.New("invalid duration '-1h', must not be negative"),
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			actual, err := ParseDuration(testItem.input)
+
+			assert.Equal(t, testItem.err, err)
+			assert.Equal(t, testItem.output, actual)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	var tests = []struct {
+		name    string
+		minutes int
+		unit    string
+		output  string
+	}{
+		{
+			name:    "No unit",
+			minutes: 90,
+			unit:    "",
+			output:  "90",
+		}, {
+			name:    "Minutes",
+			minutes: 90,
+			unit:    DurationUnitMinutes,
+			output:  "90m",
+		}, {
+			name:    "Hours",
+			minutes: 90,
+			unit:    DurationUnitHours,
+			output:  "1.5h",
+		}, {
+			name:    "Hours are rounded",
+			minutes: 10,
+			unit:    DurationUnitHours,
+			output:  "0.17h",
+		}, {
+			name:    "Human under an hour",
+			minutes: 45,
+			unit:    DurationUnitHuman,
+			output:  "45m",
+		}, {
+			name:    "Human whole hours",
+			minutes: 120,
+			unit:    DurationUnitHuman,
+			output:  "2h",
+		}, {
+			name:    "Human hours and minutes",
+			minutes: 90,
+			unit:    DurationUnitHuman,
+			output:  "1h30m",
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			assert.Equal(t, testItem.output, FormatDuration(testItem.minutes, testItem.unit))
+		})
+	}
+}
+
+func TestLegacyDurationMinutes(t *testing.T) {
+	var tests = []struct {
+		name    string
+		unit    string
+		valid   bool
+		minutes int
+	}{
+		{
+			name:    "Minutes",
+			unit:    DurationUnitMinutes,
+			valid:   true,
+			minutes: 1,
+		}, {
+			name:    "Hours",
+			unit:    DurationUnitHours,
+			valid:   true,
+			minutes: 60,
+		}, {
+			name:    "Human",
+			unit:    DurationUnitHuman,
+			valid:   false,
+			minutes: 1,
+		}, {
+			name:    "Unknown",
+			unit:    "hour",
+			valid:   false,
+			minutes: 1,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			assert.Equal(t, testItem.valid, ValidLegacyDurationUnit(testItem.unit))
+			assert.Equal(t, testItem.minutes, LegacyDurationMinutes(testItem.unit))
+		})
+	}
+}
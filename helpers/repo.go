@@ -8,25 +8,70 @@ import (
 	"github.com/spf13/viper"
 )
 
-var default_repo_path = fmt.Sprintf(".worklog%sworklog.db",
-	string(filepath.Separator))
+// Types of repository worklogs can be stored in
+const (
+	RepoTypeBolt   = "bolt"
+	RepoTypeLegacy = "legacy"
+	RepoTypeSQLite = "sqlite"
+	RepoTypeMemory = "memory"
+	RepoTypeJSONL  = "jsonl"
+	RepoTypeGit    = "git"
+	RepoTypeRemote = "remote"
+)
+
+// defaultRepoFiles the file or directory within the .worklog directory
+// each type of repository is stored in, when no path is provided
+var defaultRepoFiles = map[string]string{
+	RepoTypeBolt:   "worklog.db",
+	RepoTypeSQLite: "worklog.sqlite",
+	RepoTypeJSONL:  "worklog.jsonl",
+	RepoTypeGit:    "git",
+}
+
+// ValidRepoType whether the type is one of the types of repository
+func ValidRepoType(rType string) bool {
+	return rType == RepoTypeBolt ||
+		rType == RepoTypeLegacy ||
+		rType == RepoTypeSQLite ||
+		rType == RepoTypeMemory ||
+		rType == RepoTypeJSONL ||
+		rType == RepoTypeGit ||
+		rType == RepoTypeRemote
+}
 
 // GetRepoTypeString wrapper for checking viper if not provided
 func GetRepoTypeString(arg string) string {
 	if arg == "" {
 		arg = viper.GetString("repo.type")
 	}
-	return strings.ToLower(arg)
+	return strings.ToLower(strings.TrimSpace(arg))
 }
 
 // GetRepoPath wrapper for checking viper if not provided, and falling
-// back to a default value if neither are provided.
-func GetRepoPath(arg, homeDir string) string {
+// back to the default for the type of repository if neither are provided.
+// The configured path is only used for the configured type of repository.
+// Memory repositories have no default, as they don't need to be stored.
+func GetRepoPath(rType, arg, homeDir string) string {
 	var path string
+	if rType == "" {
+		rType = RepoTypeBolt
+	}
 	if arg == "" {
-		arg = viper.GetString("repo.path")
-		if arg == "" {
-			arg = default_repo_path
+		configuredType := GetRepoTypeString("")
+		if configuredType == "" {
+			configuredType = RepoTypeBolt
+		}
+		if configuredType == rType {
+			arg = viper.GetString("repo.path")
+		}
+		if arg == "" && rType == RepoTypeMemory {
+			return ""
+		} else if arg == "" {
+			file, ok := defaultRepoFiles[rType]
+			if !ok {
+				file = defaultRepoFiles[RepoTypeBolt]
+			}
+			arg = fmt.Sprintf(".worklog%s%s", string(filepath.Separator), file)
 		}
 	}
 	// If not absolute path
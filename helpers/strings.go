@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/microcosm-cc/bluemonday"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -77,6 +78,31 @@ func AInB(a, b string) bool {
 		strings.ToLower(a))
 }
 
+// IsAlphaNumeric check if the string is only letters and digits,
+// as generated IDs are
+func IsAlphaNumeric(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// TagPrefix ends a tag filter which matches tags starting with it
+const TagPrefix = "*"
+
+// TagMatches check if the tag is the same as the filter, ignoring case.
+// Filters ending with TagPrefix match tags starting with the rest of it.
+func TagMatches(filter, tag string) bool {
+	filter = strings.ToLower(filter)
+	tag = strings.ToLower(tag)
+	if prefix, ok := strings.CutSuffix(filter, TagPrefix); ok {
+		return strings.HasPrefix(tag, prefix)
+	}
+	return filter == tag
+}
+
 // DeduplicateString removes items when there is more than 1 of the same item
 // https://stackoverflow.com/a/66751055
 func DeduplicateString(s []string) []string {
@@ -95,3 +121,14 @@ func DeduplicateString(s []string) []string {
 func Sanitize(s string) string {
 	return policy.Sanitize(s)
 }
+
+// DefaultTagSeparator joins tags when they are written into a single field
+const DefaultTagSeparator = ";"
+
+// GetTagSeparator the configured string to join tags with
+func GetTagSeparator() string {
+	if sep := viper.GetString("default.tagSeparator"); sep != "" {
+		return sep
+	}
+	return DefaultTagSeparator
+}
@@ -106,6 +106,54 @@ func TestDeduplicateString(t *testing.T) {
 	}
 }
 
+func TestTagMatches(t *testing.T) {
+	var tests = []struct {
+		name   string
+		filter string
+		tag    string
+		exp    bool
+	}{
+		{name: "Same", filter: "go", tag: "go", exp: true},
+		{name: "Ignoring case", filter: "Go", tag: "gO", exp: true},
+		{name: "Start of tag", filter: "go", tag: "golang", exp: false},
+		{name: "Within tag", filter: "lang", tag: "golang", exp: false},
+		{name: "Prefix", filter: "GO*", tag: "golang", exp: true},
+		{name: "Prefix of the same", filter: "go*", tag: "go", exp: true},
+		{name: "Prefix within tag", filter: "lang*", tag: "golang", exp: false},
+		{name: "Only prefix", filter: "*", tag: "golang", exp: true},
+		{name: "Prefix wildcard within tag", filter: "g*g", tag: "golang", exp: false},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			assert.Equal(t, testItem.exp, TagMatches(testItem.filter, testItem.tag))
+		})
+	}
+}
+
+func TestIsAlphaNumeric(t *testing.T) {
+	var tests = []struct {
+		name string
+		in   string
+		exp  bool
+	}{
+		{name: "Generated", in: RandHexAlphaNumericString(20), exp: true},
+		{name: "Letters and digits", in: "Work2026", exp: true},
+		{name: "Empty", in: "", exp: false},
+		{name: "Parent directory", in: "../../x", exp: false},
+		{name: "Path separator", in: "a/b", exp: false},
+		{name: "Windows path separator", in: `a\b`, exp: false},
+		{name: "Punctuation", in: "a-b", exp: false},
+		{name: "Non ascii letter", in: "caf\u00e9", exp: false},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			assert.Equal(t, testItem.exp, IsAlphaNumeric(testItem.in))
+		})
+	}
+}
+
 func TestSanitise(t *testing.T) {
 	var tests = []struct {
 		name string
@@ -3,25 +3,45 @@ package model
 import (
 	"io"
 
+	"github.com/PossibleLlama/worklog/helpers"
 	"gopkg.in/yaml.v2"
 )
 
 // Defaults all options available as defaults in the configuration
 type Defaults struct {
-	Author   string `yaml:"author"`
-	Duration int    `yaml:"duration"`
-	Format   string `yaml:"format,omitempty"`
+	Author       string `yaml:"author"`
+	Duration     int    `yaml:"duration"`
+	DurationUnit string `yaml:"durationUnit,omitempty"`
+	Format       string `yaml:"format,omitempty"`
+	TagSeparator string `yaml:"tagSeparator,omitempty"`
 }
 
 type Repo struct {
-	Type string `yaml:"type"`
-	Path string `yaml:"path,omitempty"`
+	Type  string `yaml:"type"`
+	Path  string `yaml:"path,omitempty"`
+	URL   string `yaml:"url,omitempty"`
+	Token string `yaml:"token,omitempty"`
+}
+
+// Sync the worklog server to sync a local repository with
+type Sync struct {
+	URL   string `yaml:"url,omitempty"`
+	Token string `yaml:"token,omitempty"`
+}
+
+// Server options only used by the worklog server
+type Server struct {
+	Token      string `yaml:"token,omitempty"`
+	Persistent bool   `yaml:"persistent,omitempty"`
 }
 
 // Config all options available in the configuration
 type Config struct {
-	Defaults Defaults `yaml:"default"`
-	Repo     Repo     `yaml:"repo"`
+	Defaults  Defaults          `yaml:"default"`
+	Repo      Repo              `yaml:"repo"`
+	Sync      Sync              `yaml:"sync,omitempty"`
+	Server    Server            `yaml:"server,omitempty"`
+	Templates map[string]string `yaml:"templates,omitempty"`
 }
 
 // NewConfig is the generator for configuration
@@ -29,11 +49,19 @@ func NewConfig(def Defaults, repo Repo) *Config {
 	if def.Format != "pretty" &&
 		def.Format != "json" &&
 		def.Format != "yaml" &&
-		def.Format != "yml" {
+		def.Format != "yml" &&
+		def.Format != "markdown" &&
+		def.Format != "md" &&
+		def.Format != "csv" &&
+		def.Format != "tsv" {
 		def.Format = ""
 	}
-	if repo.Type != "bolt" &&
-		repo.Type != "legacy" {
+	if def.DurationUnit != helpers.DurationUnitMinutes &&
+		def.DurationUnit != helpers.DurationUnitHours &&
+		def.DurationUnit != helpers.DurationUnitHuman {
+		def.DurationUnit = ""
+	}
+	if !helpers.ValidRepoType(repo.Type) {
 		repo.Type = ""
 	}
 	return &Config{
@@ -42,6 +70,12 @@ func NewConfig(def Defaults, repo Repo) *Config {
 	}
 }
 
+// ReadConfigYAML takes a string and parses into Config
+func ReadConfigYAML(input []byte) (*Config, error) {
+	var c Config
+	return &c, yaml.Unmarshal(input, &c)
+}
+
 // WriteYAML takes a writer and outputs a YAML representation of Config to it
 func (c *Config) WriteYAML(writer io.Writer) error {
 	b, err := yaml.Marshal(&c)
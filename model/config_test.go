@@ -92,6 +92,78 @@ func TestNewConfig(t *testing.T) {
 					Path: path,
 				},
 			},
+		}, {
+			name:     "Full config: markdown",
+			author:   "Author",
+			format:   "markdown",
+			duration: 60,
+			rType:    "bolt",
+			rPath:    path,
+			expected: &Config{
+				Defaults: Defaults{
+					Author:   "Author",
+					Format:   "markdown",
+					Duration: 60,
+				},
+				Repo: Repo{
+					Type: "bolt",
+					Path: path,
+				},
+			},
+		}, {
+			name:     "Full config: md",
+			author:   "Author",
+			format:   "md",
+			duration: 60,
+			rType:    "bolt",
+			rPath:    path,
+			expected: &Config{
+				Defaults: Defaults{
+					Author:   "Author",
+					Format:   "md",
+					Duration: 60,
+				},
+				Repo: Repo{
+					Type: "bolt",
+					Path: path,
+				},
+			},
+		}, {
+			name:     "Full config: csv",
+			author:   "Author",
+			format:   "csv",
+			duration: 60,
+			rType:    "bolt",
+			rPath:    path,
+			expected: &Config{
+				Defaults: Defaults{
+					Author:   "Author",
+					Format:   "csv",
+					Duration: 60,
+				},
+				Repo: Repo{
+					Type: "bolt",
+					Path: path,
+				},
+			},
+		}, {
+			name:     "Full config: tsv",
+			author:   "Author",
+			format:   "tsv",
+			duration: 60,
+			rType:    "bolt",
+			rPath:    path,
+			expected: &Config{
+				Defaults: Defaults{
+					Author:   "Author",
+					Format:   "tsv",
+					Duration: 60,
+				},
+				Repo: Repo{
+					Type: "bolt",
+					Path: path,
+				},
+			},
 		}, {
 			name:     "Full config: invalid format",
 			author:   "Author",
@@ -200,6 +272,24 @@ func TestNewConfig(t *testing.T) {
 					Path: path,
 				},
 			},
+		}, {
+			name:     "Sqlite repo type",
+			author:   "Author",
+			format:   "yaml",
+			duration: 60,
+			rType:    "sqlite",
+			rPath:    path,
+			expected: &Config{
+				Defaults: Defaults{
+					Author:   "Author",
+					Format:   "yaml",
+					Duration: 60,
+				},
+				Repo: Repo{
+					Type: "sqlite",
+					Path: path,
+				},
+			},
 		}, {
 			name:     "Invalid repo type",
 			author:   "Author",
@@ -372,3 +462,18 @@ func TestConfigWriteYaml(t *testing.T) {
 		})
 	}
 }
+
+func TestReadConfigYAML(t *testing.T) {
+	cfg := NewConfig(Defaults{Author: "Alice", Format: "md", Duration: shortLength}, Repo{Type: "bolt"})
+	cfg.Templates = map[string]string{"Short": "{{.Title}}", "weeklyReport": "- {{.Title}}"}
+	b, err := yaml.Marshal(cfg)
+	assert.Nil(t, err)
+
+	actual, err := ReadConfigYAML(b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, cfg, actual)
+
+	_, err = ReadConfigYAML([]byte("templates: ["))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// ICSImportOptions which events of a calendar are imported as work.
+// Events starting outside of the start and end aren't imported, with
+// recurring events expanded into an instance per occurrence between
+// them. Declined events are those the attendee, matched by their name
+// or email address, has declined.
+type ICSImportOptions struct {
+	Start        time.Time
+	End          time.Time
+	Attendee     string
+	SkipDeclined bool
+	SkipAllDay   bool
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"gopkg.in/yaml.v2"
+)
+
+// Formats of exports from other time trackers which can be imported
+const (
+	ImportFormatToggl    = "toggl"
+	ImportFormatClockify = "clockify"
+	ImportFormatHarvest  = "harvest"
+)
+
+// Units the duration column of an import can be in
+const (
+	ImportDurationMinutes = "minutes"
+	ImportDurationHours   = "hours"
+	ImportDurationClock   = "clock"
+)
+
+// ImportMapping the columns of a csv file which make up a worklog.
+// Title uses the first of its columns which isn't empty, while the
+// columns for the author are joined by a space. The time column is
+// optional, and is joined to the date column when provided.
+type ImportMapping struct {
+	Title        []string `yaml:"title"`
+	Description  string   `yaml:"description,omitempty"`
+	Project      string   `yaml:"project,omitempty"`
+	Tags         string   `yaml:"tags,omitempty"`
+	TagSeparator string   `yaml:"tagSeparator,omitempty"`
+	Date         string   `yaml:"date"`
+	Time         string   `yaml:"time,omitempty"`
+	Duration     string   `yaml:"duration"`
+	DurationUnit string   `yaml:"durationUnit,omitempty"`
+	Author       []string `yaml:"author,omitempty"`
+}
+
+// NewImportMapping is the generator for the mapping of a known time
+// tracker's csv export. Unknown formats have no mapping.
+func NewImportMapping(format string) *ImportMapping {
+	switch format {
+	case ImportFormatToggl:
+		return &ImportMapping{
+			Title:        []string{"Description"},
+			Project:      "Project",
+			Tags:         "Tags",
+			TagSeparator: ",",
+			Date:         "Start date",
+			Time:         "Start time",
+			Duration:     "Duration",
+			DurationUnit: ImportDurationClock,
+			Author:       []string{"User"},
+		}
+	case ImportFormatClockify:
+		return &ImportMapping{
+			Title:        []string{"Description"},
+			Project:      "Project",
+			Tags:         "Tags",
+			TagSeparator: ",",
+			Date:         "Start Date",
+			Time:         "Start Time",
+			Duration:     "Duration (h)",
+			DurationUnit: ImportDurationClock,
+			Author:       []string{"User"},
+		}
+	case ImportFormatHarvest:
+		return &ImportMapping{
+			Title:        []string{"Notes", "Task"},
+			Project:      "Project",
+			Date:         "Date",
+			Duration:     "Hours",
+			DurationUnit: ImportDurationHours,
+			Author:       []string{"First Name", "Last Name"},
+		}
+	}
+	return nil
+}
+
+// ReadImportMappingYAML takes a byte array and returns the
+// ImportMapping it represents
+func ReadImportMappingYAML(b []byte) (*ImportMapping, error) {
+	var m ImportMapping
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
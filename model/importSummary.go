@@ -0,0 +1,63 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ImportError a single record which could not be imported
+type ImportError struct {
+	Record   int    `json:"record" yaml:"record"`
+	ID       string `json:"id,omitempty" yaml:"id,omitempty"`
+	Revision int    `json:"revision,omitempty" yaml:"revision,omitempty"`
+	Error    string `json:"error" yaml:"error"`
+}
+
+// ImportSummary the outcome of importing records of work
+type ImportSummary struct {
+	DryRun   bool          `json:"dryRun" yaml:"dryRun"`
+	Imported int           `json:"imported" yaml:"imported"`
+	Skipped  int           `json:"skipped" yaml:"skipped"`
+	Errors   []ImportError `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
+
+// AddError records a record which could not be imported
+func (s *ImportSummary) AddError(record int, w *Work, err error) {
+	importErr := ImportError{
+		Record: record,
+		Error:  err.Error(),
+	}
+	if w != nil {
+		importErr.ID = w.ID
+		importErr.Revision = w.Revision
+	}
+	s.Errors = append(s.Errors, importErr)
+}
+
+// PrettyString generates the totals of the import, followed by a line per
+// record which could not be imported
+func (s ImportSummary) PrettyString() string {
+	verb := "Imported"
+	if s.DryRun {
+		verb = "Would import"
+	}
+	finalString := fmt.Sprintf("%s: %d\nSkipped: %d\nErrors: %d\n",
+		verb, s.Imported, s.Skipped, len(s.Errors))
+	for _, importErr := range s.Errors {
+		if importErr.ID != "" {
+			finalString = fmt.Sprintf("%sRecord %d (%s, revision %d): %s\n", finalString,
+				importErr.Record, importErr.ID, importErr.Revision, importErr.Error)
+		} else {
+			finalString = fmt.Sprintf("%sRecord %d: %s\n", finalString, importErr.Record, importErr.Error)
+		}
+	}
+	return strings.TrimSpace(finalString)
+}
+
+// WritePrettyText takes a writer and outputs a text representation of the
+// ImportSummary to it
+func (s ImportSummary) WritePrettyText(writer io.Writer) error {
+	_, err := writer.Write([]byte(s.PrettyString() + "\n"))
+	return err
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportSummaryWritePrettyText(t *testing.T) {
+	var tests = []struct {
+		name    string
+		summary ImportSummary
+		errs    []error
+		exp     string
+	}{
+		{
+			name:    "Import",
+			summary: ImportSummary{Imported: 2, Skipped: 1},
+			exp:     "Imported: 2\nSkipped: 1\nErrors: 0\n",
+		}, {
+			name:    "Dry run with errors",
+			summary: ImportSummary{DryRun: true, Imported: 1},
+			errs:    []error{errors.New("bad record"), errors.New("bad id")},
+			exp: "Would import: 1\nSkipped: 0\nErrors: 2\n" +
+				"Record 1: bad record\n" +
+				"Record 2 (abc, revision 3): bad id\n",
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			for i, err := range testItem.errs {
+				var wl *Work
+				if i > 0 {
+					wl = &Work{ID: "abc", Revision: 3}
+				}
+				testItem.summary.AddError(i+1, wl, err)
+			}
+
+			var b bytes.Buffer
+			err := testItem.summary.WritePrettyText(&b)
+
+			assert.Nil(t, err)
+			assert.Equal(t, testItem.exp, b.String())
+		})
+	}
+}
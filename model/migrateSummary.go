@@ -0,0 +1,29 @@
+package model
+
+import (
+	"fmt"
+	"io"
+)
+
+// MigrateSummary the outcome of migrating work between repositories
+type MigrateSummary struct {
+	From      string `json:"from" yaml:"from"`
+	To        string `json:"to" yaml:"to"`
+	Worklogs  int    `json:"worklogs" yaml:"worklogs"`
+	Revisions int    `json:"revisions" yaml:"revisions"`
+	Checksum  string `json:"checksum" yaml:"checksum"`
+}
+
+// PrettyString generates the totals migrated, and the checksum both
+// repositories were verified against
+func (s MigrateSummary) PrettyString() string {
+	return fmt.Sprintf("Migrated %d worklogs (%d revisions) from %s to %s\nChecksum: %s",
+		s.Worklogs, s.Revisions, s.From, s.To, s.Checksum)
+}
+
+// WritePrettyText takes a writer and outputs a text representation of the
+// MigrateSummary to it
+func (s MigrateSummary) WritePrettyText(writer io.Writer) error {
+	_, err := writer.Write([]byte(s.PrettyString() + "\n"))
+	return err
+}
@@ -0,0 +1,24 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateSummaryWritePrettyText(t *testing.T) {
+	summary := MigrateSummary{
+		From:      "legacy",
+		To:        "bolt",
+		Worklogs:  2,
+		Revisions: 3,
+		Checksum:  "abc",
+	}
+
+	var b bytes.Buffer
+	err := summary.WritePrettyText(&b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Migrated 2 worklogs (3 revisions) from legacy to bolt\nChecksum: abc\n", b.String())
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"gopkg.in/yaml.v2"
+)
+
+// FieldChange a single field which differs between
+// two revisions of the same work.
+type FieldChange struct {
+	Field string `json:"field" yaml:"field"`
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+}
+
+// RevisionDiff all changes made to work between two revisions.
+type RevisionDiff struct {
+	ID      string        `json:"id" yaml:"id"`
+	From    int           `json:"fromRevision" yaml:"fromRevision"`
+	To      int           `json:"toRevision" yaml:"toRevision"`
+	Changes []FieldChange `json:"changes" yaml:"changes"`
+}
+
+// NewRevisionDiff is the generator for the changes between two revisions
+func NewRevisionDiff(from, to *Work) *RevisionDiff {
+	changes := []FieldChange{}
+	addChange := func(field, fromValue, toValue string) {
+		if fromValue != toValue {
+			changes = append(changes, FieldChange{
+				Field: field,
+				From:  fromValue,
+				To:    toValue,
+			})
+		}
+	}
+
+	addChange("title", from.Title, to.Title)
+	addChange("description", from.Description, to.Description)
+	addChange("author", from.Author, to.Author)
+	addChange("duration", strconv.Itoa(from.Duration), strconv.Itoa(to.Duration))
+	addChange("tags", strings.Join(from.Tags, ", "), strings.Join(to.Tags, ", "))
+	addChange("when", helpers.TimeFormat(from.When), helpers.TimeFormat(to.When))
+	addChange("deleted", strconv.FormatBool(from.Deleted), strconv.FormatBool(to.Deleted))
+
+	return &RevisionDiff{
+		ID:      to.ID,
+		From:    from.Revision,
+		To:      to.Revision,
+		Changes: changes,
+	}
+}
+
+// PrettyString generates a line per changed field
+func (d RevisionDiff) PrettyString() string {
+	finalString := fmt.Sprintf("ID: %s\nRevision: %d -> %d\n", d.ID, d.From, d.To)
+	if len(d.Changes) == 0 {
+		return finalString + "No changes"
+	}
+	for _, change := range d.Changes {
+		finalString = fmt.Sprintf("%s%s: '%s' -> '%s'\n", finalString, change.Field, change.From, change.To)
+	}
+	return strings.TrimSpace(finalString)
+}
+
+// WritePrettyText takes a writer and outputs a text representation of the
+// changes to it
+func (d RevisionDiff) WritePrettyText(writer io.Writer) error {
+	_, err := writer.Write([]byte(d.PrettyString() + "\n"))
+	return err
+}
+
+// WriteYAML takes a writer and outputs a YAML representation of the changes
+// to it
+func (d RevisionDiff) WriteYAML(writer io.Writer) error {
+	b, err := yaml.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(b)
+	return err
+}
+
+// WriteJSON takes a writer and outputs a JSON representation of the changes
+// to it
+func (d RevisionDiff) WriteJSON(writer io.Writer) error {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(b)
+	return err
+}
@@ -0,0 +1,103 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRevisionDiff(t *testing.T) {
+	from := genRandWork()
+	later := from.When.Add(time.Hour)
+
+	var tests = []struct {
+		name string
+		to   Work
+		exp  []FieldChange
+	}{
+		{
+			name: "No changes",
+			to:   *from,
+			exp:  []FieldChange{},
+		}, {
+			name: "Title and tags changed",
+			to: Work{
+				ID:          from.ID,
+				Revision:    from.Revision + 1,
+				Title:       "title",
+				Description: from.Description,
+				Author:      from.Author,
+				Duration:    from.Duration,
+				Tags:        []string{"alpha", "beta"},
+				When:        from.When,
+			},
+			exp: []FieldChange{
+				{Field: "title", From: from.Title, To: "title"},
+				{Field: "tags", From: from.Tags[0] + ", " + from.Tags[1], To: "alpha, beta"},
+			},
+		}, {
+			name: "Duration, when and deleted changed",
+			to: Work{
+				ID:          from.ID,
+				Revision:    from.Revision + 1,
+				Title:       from.Title,
+				Description: from.Description,
+				Author:      from.Author,
+				Duration:    from.Duration + 1,
+				Tags:        from.Tags,
+				When:        later,
+				Deleted:     true,
+			},
+			exp: []FieldChange{
+				{Field: "duration", From: "30", To: "31"},
+				{Field: "when", From: helpers.TimeFormat(from.When), To: helpers.TimeFormat(later)},
+				{Field: "deleted", From: "false", To: "true"},
+			},
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			actual := NewRevisionDiff(from, &testItem.to)
+
+			assert.Equal(t, from.ID, actual.ID)
+			assert.Equal(t, from.Revision, actual.From)
+			assert.Equal(t, testItem.to.Revision, actual.To)
+			assert.Equal(t, testItem.exp, actual.Changes)
+		})
+	}
+}
+
+func TestRevisionDiffWritePrettyText(t *testing.T) {
+	var tests = []struct {
+		name string
+		diff RevisionDiff
+		exp  string
+	}{
+		{
+			name: "No changes",
+			diff: RevisionDiff{ID: "abc", From: 1, To: 2, Changes: []FieldChange{}},
+			exp:  "ID: abc\nRevision: 1 -> 2\nNo changes\n",
+		}, {
+			name: "Multiple changes",
+			diff: RevisionDiff{ID: "abc", From: 1, To: 3, Changes: []FieldChange{
+				{Field: "title", From: "a", To: "b"},
+				{Field: "author", From: "", To: "c"},
+			}},
+			exp: "ID: abc\nRevision: 1 -> 3\ntitle: 'a' -> 'b'\nauthor: '' -> 'c'\n",
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := testItem.diff.WritePrettyText(&b)
+
+			assert.Nil(t, err)
+			assert.Equal(t, testItem.exp, b.String())
+		})
+	}
+}
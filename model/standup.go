@@ -0,0 +1,105 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// StandupDay the work completed on a single day
+type StandupDay struct {
+	Date time.Time `json:"date" yaml:"date"`
+	Work []*Work   `json:"work" yaml:"work"`
+}
+
+// Standup the work completed on the previous working day, and today
+type Standup struct {
+	Yesterday StandupDay `json:"yesterday" yaml:"yesterday"`
+	Today     StandupDay `json:"today" yaml:"today"`
+}
+
+// NewStandup is the generator for a standup
+func NewStandup(yesterday time.Time, yesterdayWork []*Work, today time.Time, todayWork []*Work) *Standup {
+	return &Standup{
+		Yesterday: StandupDay{Date: yesterday, Work: yesterdayWork},
+		Today:     StandupDay{Date: today, Work: todayWork},
+	}
+}
+
+func (d StandupDay) prettyString(heading string) string {
+	finalString := fmt.Sprintf("%s (%s)\n", heading, d.Date.Format(markdownDayFormat))
+	if len(d.Work) == 0 {
+		return finalString + "  No work recorded\n"
+	}
+	for _, work := range d.Work {
+		finalString = fmt.Sprintf("%s  %s (%s)\n", finalString, work.Title, readableDuration(work.Duration))
+	}
+	return finalString
+}
+
+func (d StandupDay) markdownString(heading string) string {
+	finalString := fmt.Sprintf("## %s (%s)\n\n", heading, d.Date.Format(markdownDayFormat))
+	if len(d.Work) == 0 {
+		return finalString + "- No work recorded\n"
+	}
+	for _, work := range d.Work {
+		finalString += work.MarkdownString() + "\n"
+	}
+	return finalString
+}
+
+// PrettyString generates a section for yesterday and today, with a
+// line per work
+func (s Standup) PrettyString() string {
+	return strings.TrimSpace(s.Yesterday.prettyString("Yesterday") + "\n" +
+		s.Today.prettyString("Today"))
+}
+
+// MarkdownString generates a heading for yesterday and today, with a
+// bullet point per work
+func (s Standup) MarkdownString() string {
+	return strings.TrimSpace(s.Yesterday.markdownString("Yesterday") + "\n" +
+		s.Today.markdownString("Today"))
+}
+
+// WritePrettyText takes a writer and outputs a text representation of the
+// Standup to it
+func (s Standup) WritePrettyText(writer io.Writer) error {
+	_, err := writer.Write([]byte(s.PrettyString() + "\n"))
+	return err
+}
+
+// WriteMarkdown takes a writer and outputs a markdown representation of the
+// Standup to it
+func (s Standup) WriteMarkdown(writer io.Writer) error {
+	_, err := writer.Write([]byte(s.MarkdownString() + "\n"))
+	return err
+}
+
+// WriteYAML takes a writer and outputs a YAML representation of the Standup
+// to it
+func (s Standup) WriteYAML(writer io.Writer) error {
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(b)
+	return err
+}
+
+// WriteJSON takes a writer and outputs a JSON representation of the Standup
+// to it
+func (s Standup) WriteJSON(writer io.Writer) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(b)
+	return err
+}
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandupWrite(t *testing.T) {
+	friday := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	standup := NewStandup(
+		friday, []*Work{
+			{Title: "First", Duration: 90, Tags: []string{"a"}},
+			{Title: "Second", Duration: 15},
+		},
+		monday, []*Work{})
+
+	var tests = []struct {
+		name      string
+		writeFunc func(*bytes.Buffer) error
+		exp       string
+	}{
+		{
+			name: "Pretty text",
+			writeFunc: func(b *bytes.Buffer) error {
+				return standup.WritePrettyText(b)
+			},
+			exp: "Yesterday (Friday 16 October 2026)\n" +
+				"  First (1h30m)\n" +
+				"  Second (15m)\n\n" +
+				"Today (Monday 19 October 2026)\n" +
+				"  No work recorded\n",
+		}, {
+			name: "Markdown",
+			writeFunc: func(b *bytes.Buffer) error {
+				return standup.WriteMarkdown(b)
+			},
+			exp: "## Yesterday (Friday 16 October 2026)\n\n" +
+				"- **First** (1h30m) `a`\n" +
+				"- **Second** (15m)\n\n" +
+				"## Today (Monday 19 October 2026)\n\n" +
+				"- No work recorded\n",
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := testItem.writeFunc(&b)
+
+			assert.Nil(t, err)
+			assert.Equal(t, testItem.exp, b.String())
+		})
+	}
+}
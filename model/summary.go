@@ -0,0 +1,154 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"gopkg.in/yaml.v2"
+)
+
+// Ways in which work can be grouped within a summary
+const (
+	SummaryGroupDay    = "day"
+	SummaryGroupWeek   = "week"
+	SummaryGroupMonth  = "month"
+	SummaryGroupTag    = "tag"
+	SummaryGroupAuthor = "author"
+)
+
+// summaryGroupNone key for work without the value being grouped by
+const summaryGroupNone = "none"
+
+// Summary totals of work, optionally split into further groups
+type Summary struct {
+	Key      string     `json:"key,omitempty" yaml:"key,omitempty"`
+	Count    int        `json:"count" yaml:"count"`
+	Duration int        `json:"duration" yaml:"duration"`
+	Groups   []*Summary `json:"groups,omitempty" yaml:"groups,omitempty"`
+}
+
+// NewSummary is the generator for a summary, nesting groups in the order
+// provided. Work with multiple tags is included within each tags group.
+func NewSummary(w []*Work, groupBy []string) (*Summary, error) {
+	for _, group := range groupBy {
+		if _, err := summaryKeys(&Work{}, group); err != nil {
+			return nil, err
+		}
+	}
+	return newSummary("", w, groupBy), nil
+}
+
+func newSummary(key string, w []*Work, groupBy []string) *Summary {
+	s := &Summary{
+		Key:   key,
+		Count: len(w),
+	}
+	for _, work := range w {
+		s.Duration += work.Duration
+	}
+	if len(groupBy) == 0 {
+		return s
+	}
+
+	grouped := make(map[string][]*Work)
+	for _, work := range w {
+		// Validated before creating the summary
+		keys, _ := summaryKeys(work, groupBy[0])
+		for _, k := range keys {
+			grouped[k] = append(grouped[k], work)
+		}
+	}
+	for k, groupedWork := range grouped {
+		s.Groups = append(s.Groups, newSummary(k, groupedWork, groupBy[1:]))
+	}
+	sort.Slice(s.Groups, func(i, j int) bool {
+		return s.Groups[i].Key < s.Groups[j].Key
+	})
+	return s
+}
+
+func summaryKeys(w *Work, group string) ([]string, error) {
+	switch group {
+	case SummaryGroupDay:
+		return []string{w.When.Format("2006-01-02")}, nil
+	case SummaryGroupWeek:
+		year, week := w.When.ISOWeek()
+		return []string{fmt.Sprintf("%d-W%02d", year, week)}, nil
+	case SummaryGroupMonth:
+		return []string{w.When.Format("2006-01")}, nil
+	case SummaryGroupTag:
+		if len(w.Tags) == 0 {
+			return []string{summaryGroupNone}, nil
+		}
+		return helpers.DeduplicateString(w.Tags), nil
+	case SummaryGroupAuthor:
+		if w.Author == "" {
+			return []string{summaryGroupNone}, nil
+		}
+		return []string{w.Author}, nil
+	}
+	return nil, fmt.Errorf("unable to group by '%s', must be one of %s",
+		group, strings.Join([]string{
+			SummaryGroupDay,
+			SummaryGroupWeek,
+			SummaryGroupMonth,
+			SummaryGroupTag,
+			SummaryGroupAuthor}, ", "))
+}
+
+// PrettyString generates an indented line for the totals of each group
+func (s Summary) PrettyString() string {
+	return strings.TrimSpace(s.prettyString(""))
+}
+
+func (s Summary) prettyString(indent string) string {
+	key := s.Key
+	if key == "" {
+		key = "Total"
+	}
+	noun := "worklogs"
+	if s.Count == 1 {
+		noun = "worklog"
+	}
+	finalString := fmt.Sprintf("%s%s: %d %s, %s\n", indent, key, s.Count, noun,
+		helpers.FormatDuration(s.Duration, helpers.GetDurationUnit()))
+	for _, group := range s.Groups {
+		finalString += group.prettyString(indent + "  ")
+	}
+	return finalString
+}
+
+// WritePrettyText takes a writer and outputs a text representation of the
+// Summary to it
+func (s Summary) WritePrettyText(writer io.Writer) error {
+	_, err := writer.Write([]byte(s.PrettyString() + "\n"))
+	return err
+}
+
+// WriteYAML takes a writer and outputs a YAML representation of the Summary
+// to it
+func (s Summary) WriteYAML(writer io.Writer) error {
+	b, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(b)
+	return err
+}
+
+// WriteJSON takes a writer and outputs a JSON representation of the Summary
+// to it
+func (s Summary) WriteJSON(writer io.Writer) error {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(b)
+	return err
+}
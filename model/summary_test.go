@@ -0,0 +1,113 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSummary(t *testing.T) {
+	monday := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	sunday := time.Date(2026, time.October, 18, 9, 0, 0, 0, time.UTC)
+	november := time.Date(2026, time.November, 2, 9, 0, 0, 0, time.UTC)
+
+	wls := []*Work{
+		{Author: "alice", Duration: 30, Tags: []string{"a", "b"}, When: monday},
+		{Author: "bob", Duration: 15, Tags: []string{"a"}, When: sunday},
+		{Duration: 60, When: november},
+	}
+
+	var tests = []struct {
+		name    string
+		groupBy []string
+		exp     *Summary
+		expErr  bool
+	}{
+		{
+			name: "Totals only",
+			exp:  &Summary{Count: 3, Duration: 105},
+		}, {
+			name:    "By day",
+			groupBy: []string{SummaryGroupDay},
+			exp: &Summary{Count: 3, Duration: 105, Groups: []*Summary{
+				{Key: "2026-10-12", Count: 1, Duration: 30},
+				{Key: "2026-10-18", Count: 1, Duration: 15},
+				{Key: "2026-11-02", Count: 1, Duration: 60},
+			}},
+		}, {
+			name:    "By month",
+			groupBy: []string{SummaryGroupMonth},
+			exp: &Summary{Count: 3, Duration: 105, Groups: []*Summary{
+				{Key: "2026-10", Count: 2, Duration: 45},
+				{Key: "2026-11", Count: 1, Duration: 60},
+			}},
+		}, {
+			name:    "By author",
+			groupBy: []string{SummaryGroupAuthor},
+			exp: &Summary{Count: 3, Duration: 105, Groups: []*Summary{
+				{Key: "alice", Count: 1, Duration: 30},
+				{Key: "bob", Count: 1, Duration: 15},
+				{Key: "none", Count: 1, Duration: 60},
+			}},
+		}, {
+			name:    "By week then tag",
+			groupBy: []string{SummaryGroupWeek, SummaryGroupTag},
+			exp: &Summary{Count: 3, Duration: 105, Groups: []*Summary{
+				{Key: "2026-W42", Count: 2, Duration: 45, Groups: []*Summary{
+					{Key: "a", Count: 2, Duration: 45},
+					{Key: "b", Count: 1, Duration: 30},
+				}},
+				{Key: "2026-W45", Count: 1, Duration: 60, Groups: []*Summary{
+					{Key: "none", Count: 1, Duration: 60},
+				}},
+			}},
+		}, {
+			name:    "Unknown grouping",
+			groupBy: []string{SummaryGroupDay, "year"},
+			expErr:  true,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			actual, err := NewSummary(wls, testItem.groupBy)
+
+			assert.Equal(t, testItem.expErr, err != nil)
+			assert.Equal(t, testItem.exp, actual)
+		})
+	}
+}
+
+func TestSummaryWritePrettyText(t *testing.T) {
+	var tests = []struct {
+		name    string
+		summary Summary
+		exp     string
+	}{
+		{
+			name:    "Single worklog",
+			summary: Summary{Count: 1, Duration: 15},
+			exp:     "Total: 1 worklog, 15\n",
+		}, {
+			name: "Nested groups",
+			summary: Summary{Count: 2, Duration: 45, Groups: []*Summary{
+				{Key: "2026-W42", Count: 2, Duration: 45, Groups: []*Summary{
+					{Key: "a", Count: 2, Duration: 45},
+				}},
+			}},
+			exp: "Total: 2 worklogs, 45\n  2026-W42: 2 worklogs, 45\n    a: 2 worklogs, 45\n",
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := testItem.summary.WritePrettyText(&b)
+
+			assert.Nil(t, err)
+			assert.Equal(t, testItem.exp, b.String())
+		})
+	}
+}
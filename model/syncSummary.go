@@ -0,0 +1,34 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SyncSummary the outcome of syncing work with a remote repository
+type SyncSummary struct {
+	Pulled    int      `json:"pulled" yaml:"pulled"`
+	Pushed    int      `json:"pushed" yaml:"pushed"`
+	Resolved  int      `json:"resolved" yaml:"resolved"`
+	Conflicts []string `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+}
+
+// PrettyString generates the totals of changes pulled from and pushed
+// to the remote, and the worklogs changed in both which were resolved,
+// followed by any which conflict
+func (s SyncSummary) PrettyString() string {
+	pretty := fmt.Sprintf("Pulled: %d\nPushed: %d\nResolved: %d",
+		s.Pulled, s.Pushed, s.Resolved)
+	if len(s.Conflicts) != 0 {
+		pretty += fmt.Sprintf("\nConflicts: %s", strings.Join(s.Conflicts, ", "))
+	}
+	return pretty
+}
+
+// WritePrettyText takes a writer and outputs a text representation of the
+// SyncSummary to it
+func (s SyncSummary) WritePrettyText(writer io.Writer) error {
+	_, err := writer.Write([]byte(s.PrettyString() + "\n"))
+	return err
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncSummaryWritePrettyText(t *testing.T) {
+	summary := SyncSummary{
+		Pulled:   3,
+		Pushed:   1,
+		Resolved: 2,
+	}
+
+	var b bytes.Buffer
+	err := summary.WritePrettyText(&b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Pulled: 3\nPushed: 1\nResolved: 2\n", b.String())
+}
+
+func TestSyncSummaryWritePrettyTextWithConflicts(t *testing.T) {
+	summary := SyncSummary{
+		Pulled:    1,
+		Conflicts: []string{"abc", "def"},
+	}
+
+	var b bytes.Buffer
+	err := summary.WritePrettyText(&b)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Pulled: 1\nPushed: 0\nResolved: 0\nConflicts: abc, def\n", b.String())
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -28,6 +29,8 @@ type Work struct {
 	When           time.Time `json:"when" yaml:"when"`
 	WhenQueryEpoch int64     `json:"whenEpoch" yaml:"whenEpoch" storm:"index"`
 	CreatedAt      time.Time `json:"createdAt" yaml:"createdAt"`
+	Deleted        bool      `json:"deleted,omitempty" yaml:"deleted,omitempty"`
+	Source         string    `json:"source,omitempty" yaml:"source,omitempty"`
 }
 
 type prettyWork struct {
@@ -84,6 +87,32 @@ func (w *Work) Update(new Work) {
 	}
 }
 
+// RevertTo changes the revision to one greater, with the contents of
+// the older revision
+func (w *Work) RevertTo(old Work) {
+	now, _ := helpers.GetStringAsDateTime(helpers.TimeFormat(time.Now()))
+	w.Revision = w.Revision + 1
+	w.CreatedAt = now
+
+	w.Title = old.Title
+	w.Description = old.Description
+	w.Author = old.Author
+	w.Duration = old.Duration
+	w.Tags = old.Tags
+	w.When = old.When
+	w.WhenQueryEpoch = old.WhenQueryEpoch
+	w.Deleted = old.Deleted
+}
+
+// MarkDeleted creates a tombstone revision, one greater than the
+// current revision, which hides the work from future reads
+func (w *Work) MarkDeleted() {
+	now, _ := helpers.GetStringAsDateTime(helpers.TimeFormat(time.Now()))
+	w.Revision = w.Revision + 1
+	w.CreatedAt = now
+	w.Deleted = true
+}
+
 // Sanitize remove all html from a wl
 func (w *Work) Sanitize() {
 	w.Title = helpers.Sanitize(w.Title)
@@ -94,6 +123,48 @@ func (w *Work) Sanitize() {
 	}
 }
 
+// HasTags checks whether the work has a tag matching each of
+// the tags, ignoring any which are empty
+func (w Work) HasTags(tags []string) bool {
+	for _, filtersTag := range tags {
+		if filtersTag == "" {
+			continue
+		}
+		found := false
+		for _, tag := range w.Tags {
+			found = found || helpers.TagMatches(filtersTag, tag)
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// FilterMatcher matches work to the filter, the same as repositories
+// querying work themselves. The title, description and author are case
+// insensitive regular expressions, and tags match as helpers.TagMatches.
+func FilterMatcher(filter *Work) (func(*Work) bool, error) {
+	if filter == nil {
+		return func(*Work) bool { return true }, nil
+	}
+	fields := []string{filter.Title, filter.Description, filter.Author}
+	res := make([]*regexp.Regexp, len(fields))
+	for i, field := range fields {
+		re, err := regexp.Compile(helpers.RegexCaseInsensitive + field)
+		if err != nil {
+			return nil, err
+		}
+		res[i] = re
+	}
+	return func(wl *Work) bool {
+		return res[0].MatchString(wl.Title) &&
+			res[1].MatchString(wl.Description) &&
+			res[2].MatchString(wl.Author) &&
+			wl.HasTags(filter.Tags)
+	}, nil
+}
+
 func workToPrettyWork(w Work) prettyWork {
 	return prettyWork{
 		ID:          w.ID,
@@ -125,7 +196,8 @@ func (w Work) String() string {
 		finalString = fmt.Sprintf("%s Author: %s,", finalString, w.Author)
 	}
 	if w.Duration != 0 {
-		finalString = fmt.Sprintf("%s Duration: %d,", finalString, w.Duration)
+		finalString = fmt.Sprintf("%s Duration: %s,", finalString,
+			helpers.FormatDuration(w.Duration, helpers.GetDurationUnit()))
 	}
 	if len(w.Tags) > 0 {
 		finalString = fmt.Sprintf("%s Tags: [%s],", finalString, strings.Join(w.Tags, ", "))
@@ -136,6 +208,12 @@ func (w Work) String() string {
 	if !w.CreatedAt.Equal(time.Time{}) {
 		finalString = fmt.Sprintf("%s CreatedAt: %s,", finalString, helpers.TimeFormat(w.CreatedAt))
 	}
+	if w.Deleted {
+		finalString = fmt.Sprintf("%s Deleted: %t,", finalString, w.Deleted)
+	}
+	if w.Source != "" {
+		finalString = fmt.Sprintf("%s Source: %s,", finalString, w.Source)
+	}
 	return strings.TrimSpace(finalString[:len(finalString)-1])
 }
 
@@ -158,7 +236,8 @@ func (w Work) StringNewLine() string {
 		finalString = fmt.Sprintf("%sAuthor: %s\n", finalString, w.Author)
 	}
 	if w.Duration != 0 {
-		finalString = fmt.Sprintf("%sDuration: %d\n", finalString, w.Duration)
+		finalString = fmt.Sprintf("%sDuration: %s\n", finalString,
+			helpers.FormatDuration(w.Duration, helpers.GetDurationUnit()))
 	}
 	if len(w.Tags) > 0 {
 		finalString = fmt.Sprintf("%sTags: [%s]\n", finalString, strings.Join(w.Tags, ", "))
@@ -169,6 +248,12 @@ func (w Work) StringNewLine() string {
 	if !w.CreatedAt.Equal(time.Time{}) {
 		finalString = fmt.Sprintf("%sCreatedAt: %s\n", finalString, helpers.TimeFormat(w.CreatedAt))
 	}
+	if w.Deleted {
+		finalString = fmt.Sprintf("%sDeleted: %t\n", finalString, w.Deleted)
+	}
+	if w.Source != "" {
+		finalString = fmt.Sprintf("%sSource: %s\n", finalString, w.Source)
+	}
 	return strings.TrimSpace(finalString[:len(finalString)-1])
 }
 
@@ -189,7 +274,8 @@ func (w Work) PrettyString() string {
 		finalString = fmt.Sprintf("%sAuthor: %s\n", finalString, pw.Author)
 	}
 	if pw.Duration != 0 {
-		finalString = fmt.Sprintf("%sDuration: %d\n", finalString, pw.Duration)
+		finalString = fmt.Sprintf("%sDuration: %s\n", finalString,
+			helpers.FormatDuration(pw.Duration, helpers.GetDurationUnit()))
 	}
 	if len(pw.Tags) > 0 {
 		finalString = fmt.Sprintf("%sTags: [%s]\n", finalString, strings.Join(pw.Tags, ", "))
@@ -346,6 +432,21 @@ func WriteAllWorkToJSON(writer io.Writer, w []*Work) error {
 	return err
 }
 
+// WriteAllWorkToNDJSON takes a writer and list of work, and outputs a JSON
+// representation of the full Work to the writer, one per line
+func WriteAllWorkToNDJSON(writer io.Writer, w []*Work) error {
+	for _, work := range w {
+		b, err := json.Marshal(work)
+		if err != nil {
+			return err
+		}
+		if _, err := writer.Write(append(b, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // WriteAllWorkToPrettyJSON takes a writer and list of work, and outputs a JSON
 // representation of Work to the writer
 func WriteAllWorkToPrettyJSON(writer io.Writer, w []*Work) error {
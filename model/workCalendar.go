@@ -0,0 +1,109 @@
+package model
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+)
+
+const (
+	// icsTimeFormat the UTC date-time format of iCalendar
+	icsTimeFormat = "20060102T150405Z"
+	// icsLineLength the octets a content line may have before being folded
+	icsLineLength = 75
+	// ICSUIDSuffix is appended to the ID of work to make its
+	// globally unique calendar identifier
+	ICSUIDSuffix = "@worklog"
+)
+
+var icsEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// WriteAllWorkToICS takes a writer and list of work, and outputs an
+// iCalendar representation of the work to the writer, as an event per work
+func WriteAllWorkToICS(writer io.Writer, w []*Work) error {
+	buf := bufio.NewWriter(writer)
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		fmt.Sprintf("PRODID:-//PossibleLlama//worklog %s//EN", helpers.Version),
+		"CALSCALE:GREGORIAN",
+		"X-WR-CALNAME:Worklog",
+	}
+	for _, work := range w {
+		lines = append(lines, work.icsEvent()...)
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := buf.WriteString(foldICSLine(line)); err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
+
+// icsEvent the content lines of the work as a VEVENT
+func (w Work) icsEvent() []string {
+	stamp := w.CreatedAt
+	if stamp.IsZero() {
+		stamp = w.When
+	}
+	start := w.When.UTC()
+	end := start.Add(time.Duration(w.Duration) * time.Minute)
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		"UID:" + icsEscaper.Replace(w.ID) + ICSUIDSuffix,
+		"DTSTAMP:" + stamp.UTC().Format(icsTimeFormat),
+		"DTSTART:" + start.Format(icsTimeFormat),
+		"DTEND:" + end.Format(icsTimeFormat),
+		"SUMMARY:" + icsEscaper.Replace(w.Title),
+	}
+	// Revisions start at 1, while sequences start at 0
+	if w.Revision > 1 {
+		lines = append(lines, fmt.Sprintf("SEQUENCE:%d", w.Revision-1))
+	}
+	if w.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+icsEscaper.Replace(w.Description))
+	}
+	if len(w.Tags) != 0 {
+		tags := make([]string, len(w.Tags))
+		for i, tag := range w.Tags {
+			tags[i] = icsEscaper.Replace(tag)
+		}
+		lines = append(lines, "CATEGORIES:"+strings.Join(tags, ","))
+	}
+	if w.Deleted {
+		lines = append(lines, "STATUS:CANCELLED")
+	}
+	return append(lines, "END:VEVENT")
+}
+
+// foldICSLine splits a content line longer than allowed over multiple
+// lines, without splitting a multi-byte character, and terminates it
+func foldICSLine(line string) string {
+	var folded strings.Builder
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > icsLineLength {
+			folded.WriteString("\r\n ")
+			// The leading space counts towards the length of the line
+			length = 1
+		}
+		folded.WriteRune(r)
+		length += size
+	}
+	folded.WriteString("\r\n")
+	return folded.String()
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestWriteAllWorkToICS(t *testing.T) {
+	when := time.Date(2026, time.October, 12, 9, 30, 0, 0, time.UTC)
+	created := time.Date(2026, time.October, 12, 11, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name      string
+		work      []*Work
+		expLines  []string
+		expAbsent []string
+	}{
+		{
+			name: "No work",
+			work: []*Work{},
+			expLines: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//PossibleLlama//worklog " + helpers.Version + "//EN",
+				"CALSCALE:GREGORIAN",
+				"X-WR-CALNAME:Worklog",
+				"END:VCALENDAR",
+			},
+		}, {
+			name: "Full work",
+			work: []*Work{{
+				ID:          "abc",
+				Revision:    3,
+				Title:       "Review; plan, ship",
+				Description: "Line one\nLine two",
+				Duration:    90,
+				Tags:        []string{"client,a", "review"},
+				When:        when,
+				CreatedAt:   created,
+			}},
+			expLines: []string{
+				"BEGIN:VEVENT",
+				"UID:abc@worklog",
+				"DTSTAMP:20261012T110000Z",
+				"DTSTART:20261012T093000Z",
+				"DTEND:20261012T110000Z",
+				`SUMMARY:Review\; plan\, ship`,
+				"SEQUENCE:2",
+				`DESCRIPTION:Line one\nLine two`,
+				`CATEGORIES:client\,a,review`,
+				"END:VEVENT",
+			},
+		}, {
+			name: "Minimal work",
+			work: []*Work{{
+				ID:       "def",
+				Revision: 1,
+				Title:    "Standup",
+				When:     when.In(time.FixedZone("BST", 3600)),
+				Deleted:  true,
+			}},
+			expLines: []string{
+				"UID:def@worklog",
+				"DTSTAMP:20261012T093000Z",
+				"DTSTART:20261012T093000Z",
+				"DTEND:20261012T093000Z",
+				"SUMMARY:Standup",
+				"STATUS:CANCELLED",
+			},
+			expAbsent: []string{"SEQUENCE", "DESCRIPTION", "CATEGORIES"},
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			var b bytes.Buffer
+
+			actualErr := WriteAllWorkToICS(&b, testItem.work)
+
+			assert.Nil(t, actualErr)
+			assert.True(t, strings.HasSuffix(b.String(), "END:VCALENDAR\r\n"))
+			lines := strings.Split(strings.TrimSuffix(b.String(), "\r\n"), "\r\n")
+			for _, expLine := range testItem.expLines {
+				assert.Contains(t, lines, expLine)
+			}
+			for _, absent := range testItem.expAbsent {
+				assert.NotContains(t, b.String(), absent)
+			}
+		})
+	}
+}
+
+func TestWriteAllWorkToICSFolding(t *testing.T) {
+	var b bytes.Buffer
+	title := strings.Repeat("a", 70) + strings.Repeat("é", 10)
+
+	assert.Nil(t, WriteAllWorkToICS(&b, []*Work{{ID: "abc", Title: title}}))
+
+	unfolded := strings.ReplaceAll(b.String(), "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:"+title+"\r\n")
+	for _, line := range strings.Split(b.String(), "\r\n") {
+		assert.LessOrEqual(t, len(line), icsLineLength)
+	}
+}
+
+func TestWriteAllWorkToICSError(t *testing.T) {
+	expErr := errors.New(helpers.RandAlphabeticString(shortLength))
+	writer := new(mockWriter)
+	writer.On("Write", mock.Anything).Return(0, expErr)
+
+	assert.Equal(t, expErr, WriteAllWorkToICS(writer, []*Work{genRandWork()}))
+}
@@ -0,0 +1,104 @@
+package model
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/PossibleLlama/worklog/helpers"
+)
+
+// Delimiters between the fields of delimited work
+const (
+	csvDelimiter = ','
+	tsvDelimiter = '\t'
+)
+
+var (
+	prettyDelimitedHeader = []string{
+		"id", "title", "description", "author", "duration", "tags", "when"}
+	allDelimitedHeader = []string{
+		"id", "revision", "title", "description", "author", "duration",
+		"tags", "when", "whenEpoch", "createdAt", "deleted", "source"}
+)
+
+func (w Work) prettyDelimitedRecord(tagSeparator string) []string {
+	return []string{
+		w.ID,
+		w.Title,
+		w.Description,
+		w.Author,
+		strconv.Itoa(w.Duration),
+		strings.Join(w.Tags, tagSeparator),
+		helpers.TimeFormat(w.When),
+	}
+}
+
+func (w Work) allDelimitedRecord(tagSeparator string) []string {
+	return []string{
+		w.ID,
+		strconv.Itoa(w.Revision),
+		w.Title,
+		w.Description,
+		w.Author,
+		strconv.Itoa(w.Duration),
+		strings.Join(w.Tags, tagSeparator),
+		helpers.TimeFormat(w.When),
+		strconv.FormatInt(w.WhenQueryEpoch, 10),
+		helpers.TimeFormat(w.CreatedAt),
+		strconv.FormatBool(w.Deleted),
+		w.Source,
+	}
+}
+
+// writeAllWorkDelimited writes a header row, followed by a row per work.
+// Durations are always written in minutes, so they can be totalled.
+func writeAllWorkDelimited(writer io.Writer, w []*Work, delimiter rune, all bool) error {
+	tagSeparator := helpers.GetTagSeparator()
+	delimited := csv.NewWriter(writer)
+	delimited.Comma = delimiter
+
+	header := prettyDelimitedHeader
+	if all {
+		header = allDelimitedHeader
+	}
+	if err := delimited.Write(header); err != nil {
+		return err
+	}
+	for _, work := range w {
+		record := work.prettyDelimitedRecord(tagSeparator)
+		if all {
+			record = work.allDelimitedRecord(tagSeparator)
+		}
+		if err := delimited.Write(record); err != nil {
+			return err
+		}
+	}
+	delimited.Flush()
+	return delimited.Error()
+}
+
+// WriteAllWorkToCSV takes a writer and list of work, and outputs a CSV
+// representation of the full Work to the writer
+func WriteAllWorkToCSV(writer io.Writer, w []*Work) error {
+	return writeAllWorkDelimited(writer, w, csvDelimiter, true)
+}
+
+// WriteAllWorkToPrettyCSV takes a writer and list of work, and outputs a CSV
+// representation of Work to the writer
+func WriteAllWorkToPrettyCSV(writer io.Writer, w []*Work) error {
+	return writeAllWorkDelimited(writer, w, csvDelimiter, false)
+}
+
+// WriteAllWorkToTSV takes a writer and list of work, and outputs a TSV
+// representation of the full Work to the writer
+func WriteAllWorkToTSV(writer io.Writer, w []*Work) error {
+	return writeAllWorkDelimited(writer, w, tsvDelimiter, true)
+}
+
+// WriteAllWorkToPrettyTSV takes a writer and list of work, and outputs a TSV
+// representation of Work to the writer
+func WriteAllWorkToPrettyTSV(writer io.Writer, w []*Work) error {
+	return writeAllWorkDelimited(writer, w, tsvDelimiter, false)
+}
@@ -0,0 +1,95 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func genDelimitedWork() *Work {
+	when := time.Date(2026, time.October, 12, 9, 30, 0, 0, time.UTC)
+	return &Work{
+		ID:             "abc",
+		Revision:       2,
+		Title:          "Title, with comma",
+		Description:    "Line one\nline \"two\"",
+		Author:         "Alice",
+		Duration:       90,
+		Tags:           []string{"alpha", "beta"},
+		When:           when,
+		WhenQueryEpoch: when.Unix(),
+		CreatedAt:      when,
+		Source:         "git:abc123",
+	}
+}
+
+func TestWriteAllWorkDelimited(t *testing.T) {
+	var tests = []struct {
+		name         string
+		tagSeparator string
+		writeFunc    func(*bytes.Buffer, []*Work) error
+		exp          string
+	}{
+		{
+			name: "Pretty CSV",
+			writeFunc: func(b *bytes.Buffer, w []*Work) error {
+				return WriteAllWorkToPrettyCSV(b, w)
+			},
+			exp: "id,title,description,author,duration,tags,when\n" +
+				"abc,\"Title, with comma\",\"Line one\nline \"\"two\"\"\",Alice,90,alpha;beta,2026-10-12T09:30:00Z\n",
+		}, {
+			name: "All CSV",
+			writeFunc: func(b *bytes.Buffer, w []*Work) error {
+				return WriteAllWorkToCSV(b, w)
+			},
+			exp: "id,revision,title,description,author,duration,tags,when,whenEpoch,createdAt,deleted,source\n" +
+				"abc,2,\"Title, with comma\",\"Line one\nline \"\"two\"\"\",Alice,90,alpha;beta," +
+				"2026-10-12T09:30:00Z,1791797400,2026-10-12T09:30:00Z,false,git:abc123\n",
+		}, {
+			name:         "Pretty TSV with tag separator",
+			tagSeparator: ", ",
+			writeFunc: func(b *bytes.Buffer, w []*Work) error {
+				return WriteAllWorkToPrettyTSV(b, w)
+			},
+			exp: "id\ttitle\tdescription\tauthor\tduration\ttags\twhen\n" +
+				"abc\tTitle, with comma\t\"Line one\nline \"\"two\"\"\"\tAlice\t90\talpha, beta\t2026-10-12T09:30:00Z\n",
+		}, {
+			name: "All TSV",
+			writeFunc: func(b *bytes.Buffer, w []*Work) error {
+				return WriteAllWorkToTSV(b, w)
+			},
+			exp: "id\trevision\ttitle\tdescription\tauthor\tduration\ttags\twhen\twhenEpoch\tcreatedAt\tdeleted\tsource\n" +
+				"abc\t2\tTitle, with comma\t\"Line one\nline \"\"two\"\"\"\tAlice\t90\talpha;beta\t" +
+				"2026-10-12T09:30:00Z\t1791797400\t2026-10-12T09:30:00Z\tfalse\tgit:abc123\n",
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			viper.Set("default.tagSeparator", testItem.tagSeparator)
+			defer viper.Set("default.tagSeparator", "")
+
+			var b bytes.Buffer
+			err := testItem.writeFunc(&b, []*Work{genDelimitedWork()})
+
+			assert.Nil(t, err)
+			assert.Equal(t, testItem.exp, b.String())
+		})
+	}
+}
+
+func TestWriteAllWorkToCSVError(t *testing.T) {
+	retErr := errors.New(helpers.RandAlphabeticString(shortLength))
+	writer := new(mockWriter)
+	writer.On("Write", mock.Anything).Return(0, retErr)
+
+	actualErr := WriteAllWorkToCSV(writer, []*Work{genRandWork()})
+
+	assert.Equal(t, retErr, actualErr)
+}
@@ -16,7 +16,8 @@ func (wList WorkList) Swap(i, j int) {
 }
 
 // RemoveOldRevisions of worklogs
-// Returns a list of the latest revisions for each ID
+// Returns a list of the latest revisions for each ID,
+// excluding any where the latest revision has been deleted
 func (wList WorkList) RemoveOldRevisions() []*Work {
 	deDuplicated := []*Work{}
 	uniqueIDWls := make(map[string][]*Work)
@@ -32,7 +33,9 @@ func (wList WorkList) RemoveOldRevisions() []*Work {
 		}
 		for _, element := range wls {
 			if element.Revision == highestRevision {
-				deDuplicated = append(deDuplicated, element)
+				if !element.Deleted {
+					deDuplicated = append(deDuplicated, element)
+				}
 				break
 			}
 		}
@@ -0,0 +1,71 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/PossibleLlama/worklog/helpers"
+)
+
+const markdownDayFormat = "Monday 02 January 2006"
+
+// readableDuration formats a duration in the configured unit,
+// defaulting to a human readable one for output read by people
+func readableDuration(minutes int) string {
+	unit := helpers.GetDurationUnit()
+	if unit == "" {
+		unit = helpers.DurationUnitHuman
+	}
+	return helpers.FormatDuration(minutes, unit)
+}
+
+// MarkdownString generates a bullet point for the Work, with the
+// description nested beneath it
+func (w Work) MarkdownString() string {
+	details := []string{readableDuration(w.Duration)}
+	if w.Author != "" {
+		details = append(details, w.Author)
+	}
+	finalString := fmt.Sprintf("- **%s** (%s)", w.Title, strings.Join(details, ", "))
+	for _, tag := range w.Tags {
+		finalString = fmt.Sprintf("%s `%s`", finalString, tag)
+	}
+
+	description := strings.TrimSpace(w.Description)
+	if description != "" {
+		finalString += "\n"
+		for _, line := range strings.Split(description, "\n") {
+			finalString = strings.TrimRight(fmt.Sprintf("%s\n  %s", finalString, line), " ")
+		}
+	}
+	return finalString
+}
+
+// WriteAllWorkToMarkdown takes a writer and list of work, and outputs a
+// markdown document of the Work grouped by day to the writer
+func WriteAllWorkToMarkdown(writer io.Writer, w []*Work) error {
+	sorted := make(WorkList, len(w))
+	copy(sorted, w)
+	sort.Stable(sorted)
+
+	day := ""
+	for index, work := range sorted {
+		if workDay := work.When.Format(markdownDayFormat); workDay != day {
+			day = workDay
+			if index != 0 {
+				if _, err := writer.Write([]byte("\n")); err != nil {
+					return err
+				}
+			}
+			if _, err := writer.Write([]byte(fmt.Sprintf("## %s\n\n", day))); err != nil {
+				return err
+			}
+		}
+		if _, err := writer.Write([]byte(work.MarkdownString() + "\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
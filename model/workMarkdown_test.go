@@ -0,0 +1,70 @@
+package model
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMarkdownString(t *testing.T) {
+	var tests = []struct {
+		name string
+		work Work
+		exp  string
+	}{
+		{
+			name: "Title and duration",
+			work: Work{Title: "Title", Duration: 90},
+			exp:  "- **Title** (1h30m)",
+		}, {
+			name: "Author and tags",
+			work: Work{Title: "Title", Author: "Alice", Duration: 15, Tags: []string{"a", "b"}},
+			exp:  "- **Title** (15m, Alice) `a` `b`",
+		}, {
+			name: "Description",
+			work: Work{Title: "Title", Description: "First line\n\nSecond line\n", Duration: 60},
+			exp:  "- **Title** (1h)\n\n  First line\n\n  Second line",
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			assert.Equal(t, testItem.exp, testItem.work.MarkdownString())
+		})
+	}
+}
+
+func TestWriteAllWorkToMarkdown(t *testing.T) {
+	monday := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	wls := []*Work{
+		{Title: "Later", Duration: 30, When: monday.AddDate(0, 0, 1)},
+		{Title: "Second", Duration: 15, When: monday.Add(time.Hour)},
+		{Title: "First", Duration: 45, When: monday},
+	}
+
+	var b bytes.Buffer
+	err := WriteAllWorkToMarkdown(&b, wls)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "## Monday 12 October 2026\n\n"+
+		"- **First** (45m)\n"+
+		"- **Second** (15m)\n\n"+
+		"## Tuesday 13 October 2026\n\n"+
+		"- **Later** (30m)\n", b.String())
+	assert.Equal(t, "Later", wls[0].Title)
+}
+
+func TestWriteAllWorkToMarkdownError(t *testing.T) {
+	retErr := errors.New(helpers.RandAlphabeticString(shortLength))
+	writer := new(mockWriter)
+	writer.On("Write", mock.Anything).Return(0, retErr)
+
+	actualErr := WriteAllWorkToMarkdown(writer, []*Work{genRandWork()})
+
+	assert.Equal(t, retErr, actualErr)
+}
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+)
+
+// templateFuncs functions available within templates of work
+var templateFuncs = template.FuncMap{
+	// date formats a time with a Go layout, such as "Mon 02 Jan"
+	"date": func(layout string, t time.Time) string {
+		return t.Format(layout)
+	},
+	// join joins a list, such as tags, with a separator
+	"join": func(sep string, s []string) string {
+		return strings.Join(s, sep)
+	},
+	// duration formats minutes in the configured unit
+	"duration": func(minutes int) string {
+		return helpers.FormatDuration(minutes, helpers.GetDurationUnit())
+	},
+	// durationIn formats minutes in the unit provided
+	"durationIn": func(unit string, minutes int) string {
+		return helpers.FormatDuration(minutes, unit)
+	},
+	// truncate shortens a string to at most length characters
+	"truncate": func(length int, s string) string {
+		runes := []rune(s)
+		if length < 0 || len(runes) <= length {
+			return s
+		}
+		return string(runes[:length])
+	},
+}
+
+// ParseWorkTemplate parses a template to render work with,
+// including the worklog template functions
+func ParseWorkTemplate(text string) (*template.Template, error) {
+	return template.New("worklog").Funcs(templateFuncs).Parse(text)
+}
+
+// WriteAllWorkToTemplate takes a writer, a list of work and a template, and
+// outputs each Work rendered by the template on a new line to the writer
+func WriteAllWorkToTemplate(writer io.Writer, w []*Work, text string) error {
+	tmpl, err := ParseWorkTemplate(text)
+	if err != nil {
+		return err
+	}
+
+	for _, work := range w {
+		if err := tmpl.Execute(writer, work); err != nil {
+			return err
+		}
+		if _, err := writer.Write([]byte("\n")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteAllWorkToTemplate(t *testing.T) {
+	when := time.Date(2026, time.October, 12, 9, 30, 0, 0, time.UTC)
+	wls := []*Work{
+		{Title: "First", Duration: 90, Tags: []string{"a", "b"}, When: when},
+		{Title: "Second piece of work", Duration: 15, When: when.AddDate(0, 0, 1)},
+	}
+
+	var tests = []struct {
+		name     string
+		template string
+		exp      string
+		expErr   bool
+	}{
+		{
+			name:     "Fields",
+			template: "{{.Title}} ({{.Duration}})",
+			exp:      "First (90)\nSecond piece of work (15)\n",
+		}, {
+			name:     "Date",
+			template: `{{.When | date "Mon"}} {{.Title}}`,
+			exp:      "Mon First\nTue Second piece of work\n",
+		}, {
+			name:     "Join",
+			template: `{{.Tags | join ", "}}`,
+			exp:      "a, b\n\n",
+		}, {
+			name:     "Duration",
+			template: `{{.Duration | duration}} {{.Duration | durationIn "human"}}`,
+			exp:      "90 1h30m\n15 15m\n",
+		}, {
+			name:     "Truncate",
+			template: `{{.Title | truncate 6}}`,
+			exp:      "First\nSecond\n",
+		}, {
+			name:     "Invalid template",
+			template: "{{.Title",
+			expErr:   true,
+		}, {
+			name:     "Unknown field",
+			template: "{{.Foo}}",
+			expErr:   true,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := WriteAllWorkToTemplate(&b, wls, testItem.template)
+
+			assert.Equal(t, testItem.expErr, err != nil)
+			if !testItem.expErr {
+				assert.Equal(t, testItem.exp, b.String())
+			}
+		})
+	}
+}
@@ -187,6 +187,50 @@ func TestUpdate(t *testing.T) {
 	assert.Equal(t, new.Tags, wOg.Tags)
 }
 
+func TestRevertTo(t *testing.T) {
+	old := genRandWork()
+	w := genRandWork()
+	w.ID = old.ID
+	w.Revision = 4
+	w.Deleted = true
+	w.CreatedAt = time.Date(2020, time.January, 30, 23, 59, 0, 0, time.UTC)
+	createdAt := w.CreatedAt
+
+	w.RevertTo(*old)
+
+	assert.Equal(t, old.ID, w.ID)
+	assert.Equal(t, 5, w.Revision)
+	assert.Equal(t, old.Title, w.Title)
+	assert.Equal(t, old.Description, w.Description)
+	assert.Equal(t, old.Author, w.Author)
+	assert.Equal(t, old.Duration, w.Duration)
+	assert.Equal(t, old.Tags, w.Tags)
+	assert.Equal(t, old.When, w.When)
+	assert.Equal(t, old.WhenQueryEpoch, w.WhenQueryEpoch)
+	assert.False(t, w.Deleted)
+	assert.True(t, createdAt.Before(w.CreatedAt))
+}
+
+func TestMarkDeleted(t *testing.T) {
+	w := genRandWork()
+	w.CreatedAt = time.Date(2020, time.January, 30, 23, 59, 0, 0, time.UTC)
+	wCopy := *w
+
+	w.MarkDeleted()
+
+	assert.Equal(t, wCopy.ID, w.ID)
+	assert.Equal(t, wCopy.Revision+1, w.Revision)
+	assert.Equal(t, wCopy.Title, w.Title)
+	assert.Equal(t, wCopy.Description, w.Description)
+	assert.Equal(t, wCopy.Author, w.Author)
+	assert.Equal(t, wCopy.Duration, w.Duration)
+	assert.Equal(t, wCopy.Tags, w.Tags)
+	assert.Equal(t, wCopy.When, w.When)
+	assert.True(t, wCopy.CreatedAt.Before(w.CreatedAt))
+	assert.False(t, wCopy.Deleted)
+	assert.True(t, w.Deleted)
+}
+
 func TestSanitize(t *testing.T) {
 	newTag := helpers.RandAlphabeticString(shortLength)
 	wSafe := genRandWork()
@@ -1121,3 +1165,88 @@ func TestWriteAllToPrettyJson(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteAllToNDJson(t *testing.T) {
+	var tests = []struct {
+		name   string
+		work   []*Work
+		retErr error
+	}{
+		{
+			name:   "No error single",
+			work:   []*Work{genRandWork()},
+			retErr: nil,
+		}, {
+			name:   "No error double",
+			work:   []*Work{genRandWork(), genRandWork()},
+			retErr: nil,
+		}, {
+			name:   "Erroring",
+			work:   []*Work{genRandWork(), genRandWork()},
+			retErr: errors.New(helpers.RandAlphabeticString(shortLength)),
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			writer := new(mockWriter)
+
+			for _, element := range testItem.work {
+				bytes, _ := json.Marshal(element)
+				writer.On("Write", append(bytes, '\n')).Return(1, testItem.retErr)
+			}
+
+			actualErr := WriteAllWorkToNDJSON(writer, testItem.work)
+
+			if testItem.retErr == nil {
+				writer.AssertNumberOfCalls(t, "Write", len(testItem.work))
+			} else {
+				writer.AssertNumberOfCalls(t, "Write", 1)
+			}
+			assert.Equal(t, testItem.retErr, actualErr)
+		})
+	}
+}
+
+func TestFilterMatcher(t *testing.T) {
+	w := Work{
+		Title:       "Write Docs",
+		Description: "For the export",
+		Author:      "Alice",
+		Tags:        []string{"client-a", "docs"},
+	}
+
+	var tests = []struct {
+		name   string
+		filter *Work
+		exp    bool
+		expErr bool
+	}{
+		{name: "No filter", filter: nil, exp: true},
+		{name: "Empty filter", filter: &Work{}, exp: true},
+		{name: "Matching title ignoring case", filter: &Work{Title: "docs"}, exp: true},
+		{name: "Title expression", filter: &Work{Title: "^write.*s$"}, exp: true},
+		{name: "Title expression not matching", filter: &Work{Title: "^docs"}, exp: false},
+		{name: "Different description", filter: &Work{Description: "import"}, exp: false},
+		{name: "Different author", filter: &Work{Author: "Bob"}, exp: false},
+		{name: "Author alternatives", filter: &Work{Author: "bob|alice"}, exp: true},
+		{name: "Invalid expression", filter: &Work{Author: "("}, expErr: true},
+		{name: "All tags", filter: &Work{Tags: []string{"client-a", "docs"}}, exp: true},
+		{name: "Missing tag", filter: &Work{Tags: []string{"client-b"}}, exp: false},
+		{name: "Part of a tag", filter: &Work{Tags: []string{"client"}}, exp: false},
+		{name: "Tag prefix", filter: &Work{Tags: []string{"CLIENT-*", "docs"}}, exp: true},
+		{name: "Empty tag", filter: &Work{Tags: []string{""}}, exp: true},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			matches, err := FilterMatcher(testItem.filter)
+			if testItem.expErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, testItem.exp, matches(&w))
+		})
+	}
+}
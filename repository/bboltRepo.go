@@ -1,10 +1,14 @@
 package repository
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	e "github.com/PossibleLlama/worklog/errors"
@@ -16,26 +20,76 @@ import (
 	bolt "go.etcd.io/bbolt"
 )
 
-var filePath string
+const (
+	timerBucket = "timer"
+	timerKey    = "active"
 
-type bboltRepo struct{}
+	metaBucket           = "meta"
+	durationsMigratedKey = "durationsInMinutes"
+	tagsIndexedKey       = "tagsIndexed"
 
-// NewBBoltRepo initializes the repo with the given filepath
+	// tagBucket indexes work by each of its tags, then when it was.
+	// Keys are the lowercase tag, a separator, the when as sortable
+	// bytes and the ID.
+	tagBucket    = "tags"
+	tagSeparator = 0x00
+
+	syncBucket = "sync"
+)
+
+type bboltRepo struct {
+	path string
+
+	// persistent repositories hold the database open until closed
+	persistent bool
+	lock       sync.Mutex
+	db         *storm.DB
+
+	// legacyDurationMinutes in each unit of a duration recorded
+	// before durations were stored as minutes
+	legacyDurationMinutes int
+}
+
+// revision stores every revision of work. Work itself is
+// keyed by ID, so only holds the latest revision.
+type revision struct {
+	Key    string `storm:"id"`
+	WorkID string `storm:"index"`
+	Work   model.Work
+}
+
+func newRevision(wl *model.Work) *revision {
+	return &revision{
+		Key:    fmt.Sprintf("%s_%d", wl.ID, wl.Revision),
+		WorkID: wl.ID,
+		Work:   *wl,
+	}
+}
+
+// NewBBoltRepo initializes the repo with the given filepath,
+// which is only opened while in use
 func NewBBoltRepo(path string) WorklogRepository {
-	filePath = path
-	return &bboltRepo{}
+	return &bboltRepo{path: path}
 }
 
-func (*bboltRepo) Init() error {
+// NewPersistentBBoltRepo initializes the repo with the given filepath,
+// which is held open until the repo is closed. This suits long-running
+// processes such as the server, as reads don't wait on opening the file,
+// although no other process can use the file while it is open.
+func NewPersistentBBoltRepo(path string) WorklogRepository {
+	return &bboltRepo{path: path, persistent: true}
+}
+
+func (r *bboltRepo) Init() error {
 	var foundWls []*model.Work
 
-	db, openErr := openReadWrite()
+	db, openErr := r.openReadWrite()
 	if openErr != nil {
 		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
 		return openErr
 	}
 	defer func() {
-		_ = db.Close()
+		r.release(db)
 	}()
 
 	viewErr := db.Select(
@@ -61,22 +115,121 @@ func (*bboltRepo) Init() error {
 		helpers.LogError("failed to reindex database", "update db - bolt")
 	}
 
+	if err := migrateDurations(db, r.legacyDurationMinutes); err != nil {
+		helpers.LogError(fmt.Sprintf("failed to update durations. error: %s", err.Error()), "update db error - bolt")
+		return err
+	}
+
+	if err := db.Bolt.Update(func(btx *bolt.Tx) error {
+		return indexAllTags(btx, db.WithTransaction(btx))
+	}); err != nil {
+		helpers.LogError(fmt.Sprintf("failed to index tags. error: %s", err.Error()), "update db error - bolt")
+		return err
+	}
+
 	return nil
 }
 
-func (*bboltRepo) Save(wl *model.Work) error {
-	db, openErr := openReadWrite()
+// SetLegacyDurationMinutes in each unit of durations recorded before they
+// were stored as minutes, to convert them when initialised
+func (r *bboltRepo) SetLegacyDurationMinutes(minutes int) {
+	r.legacyDurationMinutes = minutes
+}
+
+// migrateDurations converts durations recorded before they were stored as
+// minutes, each unit being the given minutes. This only happens once for
+// each database, so durations aren't marked as converted until a unit
+// other than minutes is given.
+func migrateDurations(db *storm.DB, minutes int) error {
+	if minutes <= 1 {
+		return nil
+	}
+	var migrated bool
+	getErr := db.Get(metaBucket, durationsMigratedKey, &migrated)
+	if getErr != nil && getErr != storm.ErrNotFound {
+		return getErr
+	} else if migrated {
+		return nil
+	}
+
+	tx, err := db.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var all []*model.Work
+	var revs []*revision
+	if err := tx.All(&all); err != nil {
+		return err
+	}
+	if err := tx.All(&revs); err != nil {
+		return err
+	}
+
+	helpers.LogDebug(fmt.Sprintf("updating durations of %d items to minutes", len(all)), "update db - bolt")
+	for _, el := range all {
+		if err := tx.UpdateField(&model.Work{ID: el.ID}, "Duration", el.Duration*minutes); err != nil {
+			return err
+		}
+	}
+	for _, rev := range revs {
+		rev.Work.Duration = rev.Work.Duration * minutes
+		if err := tx.Save(rev); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Set(metaBucket, durationsMigratedKey, true); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *bboltRepo) Save(wl *model.Work) error {
+	db, openErr := r.openReadWrite()
 	if openErr != nil {
 		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
 		return openErr
 	}
 	defer func() {
-		_ = db.Close()
+		r.release(db)
 	}()
 
+	btx, err := db.Bolt.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = btx.Rollback()
+	}()
+	tx := db.WithTransaction(btx)
+
 	helpers.LogDebug("Saving file...", "save model - bolt")
-	if err := db.Save(wl); err != nil {
-		helpers.LogError(fmt.Sprintf("Error closing file: %s", err.Error()), "save model error - bolt")
+	if err := tx.Save(newRevision(wl)); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving revision: %s", err.Error()), "save model error - bolt")
+		return err
+	}
+
+	// Older revisions are only kept in the history
+	var current model.Work
+	currentErr := tx.One("ID", wl.ID, &current)
+	if currentErr != nil && currentErr != storm.ErrNotFound {
+		return currentErr
+	}
+	if currentErr == storm.ErrNotFound || current.Revision <= wl.Revision {
+		if err := tx.Save(wl); err != nil {
+			helpers.LogError(fmt.Sprintf("Error closing file: %s", err.Error()), "save model error - bolt")
+			return err
+		}
+		if err := reindexTags(btx, tx, &current, wl, currentErr == nil); err != nil {
+			helpers.LogError(fmt.Sprintf("Error indexing tags: %s", err.Error()), "save model error - bolt")
+			return err
+		}
+	}
+	if err := btx.Commit(); err != nil {
 		return err
 	}
 
@@ -84,17 +237,75 @@ func (*bboltRepo) Save(wl *model.Work) error {
 	return nil
 }
 
-func (*bboltRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
+func (r *bboltRepo) Delete(id string) error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
+		return openErr
+	}
+	defer func() {
+		r.release(db)
+	}()
+
+	btx, err := db.Bolt.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = btx.Rollback()
+	}()
+	tx := db.WithTransaction(btx)
+
+	helpers.LogDebug("Deleting worklog...", "delete model - bolt")
+	var current model.Work
+	if err := tx.One("ID", id, &current); err == storm.ErrNotFound {
+		return errors.New(e.RepoDeleteNotFound)
+	} else if err != nil {
+		return err
+	}
+	if err := unindexTags(btx, &current); err != nil {
+		helpers.LogError(fmt.Sprintf("Error removing tags: %s", err.Error()), "delete model error - bolt")
+		return err
+	}
+	if err := tx.DeleteStruct(&model.Work{ID: id}); err != nil {
+		if err == storm.ErrNotFound {
+			return errors.New(e.RepoDeleteNotFound)
+		}
+		helpers.LogError(fmt.Sprintf("Error deleting worklog: %s", err.Error()), "delete model error - bolt")
+		return err
+	}
+	if err := tx.Select(q.Eq("WorkID", id)).Delete(&revision{}); err != nil && err != storm.ErrNotFound {
+		helpers.LogError(fmt.Sprintf("Error deleting revisions: %s", err.Error()), "delete model error - bolt")
+		return err
+	}
+	if err := btx.Commit(); err != nil {
+		return err
+	}
+
+	helpers.LogDebug("Deleted worklog", "delete model successful - bolt")
+	return nil
+}
+
+func (r *bboltRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
 	var foundWls, filteredWls []*model.Work
-	db, openErr := openReadOnly()
+	db, openErr := r.openReadOnly()
 	if openErr != nil {
 		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
 		return nil, openErr
 	}
 	defer func() {
-		_ = db.Close()
+		r.release(db)
 	}()
 
+	if filter != nil && hasTags(filter.Tags) {
+		tagged, indexed, err := getByTags(db, startDate, endDate, filter)
+		if err != nil {
+			return nil, errors.New("failed to get from db between dates")
+		} else if indexed {
+			return tagged, nil
+		}
+	}
+
 	sel := q.And(
 		q.Gte("WhenQueryEpoch", startDate.Unix()),
 		q.Lt("WhenQueryEpoch", endDate.Unix()),
@@ -105,8 +316,9 @@ func (*bboltRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model
 		return nil, errors.New("failed to get from db between dates")
 	}
 
+	// Databases without the tag index are filtered here instead
 	for _, el := range foundWls {
-		if filterByTags(filter, el) {
+		if filter == nil || el.HasTags(filter.Tags) {
 			el.Sanitize()
 			filteredWls = append(filteredWls, el)
 		}
@@ -118,15 +330,15 @@ func (*bboltRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model
 	return filteredWls, viewErr
 }
 
-func (*bboltRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
+func (r *bboltRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
 	var foundWls []*model.Work
-	db, openErr := openReadOnly()
+	db, openErr := r.openReadOnly()
 	if openErr != nil {
 		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
 		return nil, openErr
 	}
 	defer func() {
-		_ = db.Close()
+		r.release(db)
 	}()
 
 	sel := q.And(
@@ -137,43 +349,195 @@ func (*bboltRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
 
 	if viewErr == storm.ErrNotFound {
 		return nil, nil
-	} else if viewErr == nil && len(foundWls) > 1 {
+	} else if viewErr != nil || len(foundWls) == 0 {
+		return nil, viewErr
+	} else if len(foundWls) > 1 {
 		return nil, errors.New(e.RepoGetSingleFileAmbiguous)
+	} else if filter != nil && !foundWls[0].HasTags(filter.Tags) {
+		return nil, nil
 	}
-	if !filterByTags(filter, foundWls[0]) {
+	foundWls[0].Sanitize()
+	return foundWls[0], nil
+}
+
+func (r *bboltRepo) GetRevisions(ID string) ([]*model.Work, error) {
+	var foundWls []*model.Work
+	var revs []*revision
+	db, openErr := r.openReadOnly()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
+		return nil, openErr
+	}
+	defer func() {
+		r.release(db)
+	}()
+
+	viewErr := db.Select(q.Re("ID", helpers.RegexCaseInsensitive+ID)).Find(&foundWls)
+	if viewErr == storm.ErrNotFound {
+		return []*model.Work{}, nil
+	} else if viewErr != nil {
 		return nil, viewErr
+	} else if len(foundWls) > 1 {
+		return nil, errors.New(e.RepoGetSingleFileAmbiguous)
 	}
-	foundWls[0].Sanitize()
-	return foundWls[0], viewErr
+
+	revErr := db.Find("WorkID", foundWls[0].ID, &revs)
+	if revErr != nil && revErr != storm.ErrNotFound {
+		return nil, revErr
+	}
+	return mergeRevisions(foundWls, revs), nil
 }
 
-func (*bboltRepo) GetAll() ([]*model.Work, error) {
+func (r *bboltRepo) GetAll() ([]*model.Work, error) {
 	var all []*model.Work
-	db, openErr := openReadOnly()
+	var revs []*revision
+	db, openErr := r.openReadOnly()
 	if openErr != nil {
 		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
 		return nil, openErr
 	}
 	defer func() {
-		_ = db.Close()
+		r.release(db)
+	}()
+
+	if err := db.All(&all); err != nil {
+		return nil, err
+	}
+	if err := db.All(&revs); err != nil {
+		return nil, err
+	}
+	return mergeRevisions(all, revs), nil
+}
+
+func (r *bboltRepo) SaveTimer(wl *model.Work) error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
+		return openErr
+	}
+	defer func() {
+		r.release(db)
+	}()
+
+	if err := db.Set(timerBucket, timerKey, wl); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving timer: %s", err.Error()), "save timer error - bolt")
+		return err
+	}
+	return nil
+}
+
+func (r *bboltRepo) GetTimer() (*model.Work, error) {
+	// Nothing has been saved yet, so no timer is running
+	if _, err := os.Stat(r.path); !r.persistent && os.IsNotExist(err) {
+		return nil, nil
+	}
+	var wl model.Work
+	db, openErr := r.openReadOnly()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
+		return nil, openErr
+	}
+	defer func() {
+		r.release(db)
+	}()
+
+	err := db.Get(timerBucket, timerKey, &wl)
+	if err == storm.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	wl.Sanitize()
+	return &wl, nil
+}
+
+func (r *bboltRepo) DeleteTimer() error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
+		return openErr
+	}
+	defer func() {
+		r.release(db)
 	}()
 
-	err := db.All(&all)
-	return all, err
+	err := db.Delete(timerBucket, timerKey)
+	if err != nil && err != storm.ErrNotFound {
+		helpers.LogError(fmt.Sprintf("Error deleting timer: %s", err.Error()), "delete timer error - bolt")
+		return err
+	}
+	return nil
+}
+
+// GetSyncMarks the revision of each worklog last synced with the remote
+func (r *bboltRepo) GetSyncMarks(remote string) (map[string]int, error) {
+	marks := make(map[string]int)
+	db, openErr := r.openReadOnly()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
+		return nil, openErr
+	}
+	defer func() {
+		r.release(db)
+	}()
+
+	err := db.Get(syncBucket, remote, &marks)
+	if err != nil && err != storm.ErrNotFound {
+		return nil, err
+	}
+	return marks, nil
+}
+
+// SaveSyncMarks replaces the revisions of worklogs synced with the remote
+func (r *bboltRepo) SaveSyncMarks(remote string, marks map[string]int) error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - bolt")
+		return openErr
+	}
+	defer func() {
+		r.release(db)
+	}()
+
+	if err := db.Set(syncBucket, remote, marks); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving sync marks: %s", err.Error()), "save sync marks error - bolt")
+		return err
+	}
+	return nil
+}
+
+// Close the database if it is held open
+func (r *bboltRepo) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.db == nil {
+		return nil
+	}
+	helpers.LogDebug("Closing database...", "close db - bolt")
+	err := r.db.Close()
+	r.db = nil
+	return err
 }
 
 // Internal wrapped function to ensure all usages are aligned
-func openReadWrite() (*storm.DB, error) {
-	return storm.Open(filePath, storm.BoltOptions(0750, &bolt.Options{
+func (r *bboltRepo) openReadWrite() (*storm.DB, error) {
+	if r.persistent {
+		return r.openPersistent()
+	}
+	return storm.Open(r.path, storm.BoltOptions(0750, &bolt.Options{
 		Timeout:  1 * time.Second,
 		ReadOnly: false,
 	}))
 }
 
 // Internal wrapped function to ensure all usages are aligned
-func openReadOnly() (*storm.DB, error) {
-	if _, err := os.Stat(filePath); err == nil {
-		return storm.Open(filePath, storm.BoltOptions(0750, &bolt.Options{
+func (r *bboltRepo) openReadOnly() (*storm.DB, error) {
+	if r.persistent {
+		return r.openPersistent()
+	}
+	if _, err := os.Stat(r.path); err == nil {
+		return storm.Open(r.path, storm.BoltOptions(0750, &bolt.Options{
 			Timeout:  1 * time.Second,
 			ReadOnly: true,
 		}))
@@ -181,7 +545,63 @@ func openReadOnly() (*storm.DB, error) {
 	return nil, fmt.Errorf(e.RepoGetFilesRead)
 }
 
+// openPersistent the database held open for reading and writing,
+// opening it the first time. Each read is its own transaction,
+// so reads happen concurrently.
+func (r *bboltRepo) openPersistent() (*storm.DB, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.db == nil {
+		db, err := storm.Open(r.path, storm.BoltOptions(0750, &bolt.Options{
+			Timeout:  1 * time.Second,
+			ReadOnly: false,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		r.db = db
+	}
+	return r.db, nil
+}
+
+// release the database after use, unless it is held open
+func (r *bboltRepo) release(db *storm.DB) {
+	if !r.persistent {
+		_ = db.Close()
+	}
+}
+
+// mergeRevisions combines the latest work with the stored history.
+// Work saved before history was kept only has its latest revision.
+func mergeRevisions(latest []*model.Work, revs []*revision) []*model.Work {
+	merged := []*model.Work{}
+	seen := make(map[string]bool)
+	for _, rev := range revs {
+		wl := rev.Work
+		wl.Sanitize()
+		seen[rev.Key] = true
+		merged = append(merged, &wl)
+	}
+	for _, wl := range latest {
+		if !seen[newRevision(wl).Key] {
+			wl.Sanitize()
+			merged = append(merged, wl)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		if merged[i].ID != merged[j].ID {
+			return merged[i].ID < merged[j].ID
+		}
+		return merged[i].Revision < merged[j].Revision
+	})
+	return merged
+}
+
 func filterQuery(f *model.Work) q.Matcher {
+	if f == nil {
+		return q.And()
+	}
 	sel := q.And(
 		q.Re("Title", helpers.RegexCaseInsensitive+f.Title),
 		q.Re("Description", helpers.RegexCaseInsensitive+f.Description),
@@ -190,16 +610,191 @@ func filterQuery(f *model.Work) q.Matcher {
 	return sel
 }
 
-// Check if the filters tags exist in the provided work.
-// Returns true if it matches, or false if not.
-func filterByTags(f *model.Work, w *model.Work) bool {
-	if f == nil || w == nil {
-		return false
+// getByTags finds work with every tag of the filter using the tag index,
+// only reading work which has the tags within the dates. Whether the
+// database is indexed is returned, as databases created before the
+// index existed aren't until next saved to.
+func getByTags(db *storm.DB, startDate, endDate time.Time, filter *model.Work) ([]*model.Work, bool, error) {
+	found := []*model.Work{}
+	indexed := false
+	err := db.Bolt.View(func(btx *bolt.Tx) error {
+		tx := db.WithTransaction(btx)
+		if err := tx.Get(metaBucket, tagsIndexedKey, &indexed); err != nil && err != storm.ErrNotFound {
+			return err
+		} else if !indexed {
+			return nil
+		}
+
+		matches := filterQuery(filter)
+		for _, id := range taggedIDs(btx.Bucket([]byte(tagBucket)), filter.Tags, startDate.Unix(), endDate.Unix()) {
+			var wl model.Work
+			if err := tx.One("ID", id, &wl); err == storm.ErrNotFound {
+				continue
+			} else if err != nil {
+				return err
+			}
+			if ok, err := matches.Match(&wl); err != nil {
+				return err
+			} else if ok {
+				wl.Sanitize()
+				found = append(found, &wl)
+			}
+		}
+		return nil
+	})
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].WhenQueryEpoch < found[j].WhenQueryEpoch
+	})
+	return found, indexed, err
+}
+
+// taggedIDs the IDs of work with every tag, from start until end
+func taggedIDs(b *bolt.Bucket, tags []string, start, end int64) []string {
+	if b == nil {
+		return []string{}
+	}
+	var ids map[string]bool
+	for _, tag := range tags {
+		if tag == "" {
+			continue
+		}
+		matched := make(map[string]bool)
+		for _, id := range scanTag(b.Cursor(), tag, start, end) {
+			if ids == nil || ids[id] {
+				matched[id] = true
+			}
+		}
+		ids = matched
+	}
+
+	sorted := []string{}
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// scanTag the IDs of work with tags matching the filter, from start until
+// end. Keys of each tag are sorted by when, so those outside the dates
+// are skipped over rather than read.
+func scanTag(c *bolt.Cursor, filter string, start, end int64) []string {
+	prefix, isPrefix := strings.CutSuffix(strings.ToLower(filter), helpers.TagPrefix)
+	if !isPrefix {
+		prefix += string(rune(tagSeparator))
+	}
+
+	ids := []string{}
+	k, _ := c.Seek([]byte(prefix))
+	for k != nil && bytes.HasPrefix(k, []byte(prefix)) {
+		tag, when, id, ok := parseTagKey(k)
+		if !ok {
+			k, _ = c.Next()
+		} else if when < start {
+			k, _ = c.Seek(tagKey(tag, start, ""))
+		} else if when >= end {
+			// Past the end of this tag, so on to the next one
+			k, _ = c.Seek([]byte(tag + string(rune(tagSeparator+1))))
+		} else {
+			ids = append(ids, id)
+			k, _ = c.Next()
+		}
+	}
+	return ids
+}
+
+// tagKey the key of the tag index for work with the tag, when and ID.
+// The sign bit of when is flipped, so earlier times sort first.
+func tagKey(tag string, when int64, id string) []byte {
+	key := make([]byte, 0, len(tag)+9+len(id))
+	key = append(key, strings.ToLower(tag)...)
+	key = append(key, tagSeparator)
+	key = binary.BigEndian.AppendUint64(key, uint64(when)^(1<<63))
+	return append(key, id...)
+}
+
+// parseTagKey the tag, when and ID of a key of the tag index
+func parseTagKey(key []byte) (string, int64, string, bool) {
+	i := bytes.IndexByte(key, tagSeparator)
+	if i < 0 || len(key) < i+9 {
+		return "", 0, "", false
+	}
+	when := int64(binary.BigEndian.Uint64(key[i+1:i+9]) ^ (1 << 63))
+	return string(key[:i]), when, string(key[i+9:]), true
+}
+
+// hasTags whether any of the tags filter work
+func hasTags(tags []string) bool {
+	for _, tag := range tags {
+		if tag != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// indexTags adds the work to the tag index
+func indexTags(btx *bolt.Tx, wl *model.Work) error {
+	b, err := btx.CreateBucketIfNotExists([]byte(tagBucket))
+	if err != nil {
+		return err
+	}
+	for _, tag := range wl.Tags {
+		if tag != "" {
+			if err := b.Put(tagKey(tag, wl.When.Unix(), wl.ID), []byte{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// unindexTags removes the work from the tag index
+func unindexTags(btx *bolt.Tx, wl *model.Work) error {
+	b := btx.Bucket([]byte(tagBucket))
+	if b == nil {
+		return nil
+	}
+	for _, tag := range wl.Tags {
+		if err := b.Delete(tagKey(tag, wl.When.Unix(), wl.ID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindexTags replaces the previous work in the tag index with the
+// work now saved, indexing all work first if it isn't already
+func reindexTags(btx *bolt.Tx, tx storm.Node, previous, wl *model.Work, replaced bool) error {
+	if err := indexAllTags(btx, tx); err != nil {
+		return err
+	}
+	if replaced {
+		if err := unindexTags(btx, previous); err != nil {
+			return err
+		}
+	}
+	return indexTags(btx, wl)
+}
+
+// indexAllTags adds all work to the tag index, if it hasn't been already
+func indexAllTags(btx *bolt.Tx, tx storm.Node) error {
+	var indexed bool
+	if err := tx.Get(metaBucket, tagsIndexedKey, &indexed); err != nil && err != storm.ErrNotFound {
+		return err
+	} else if indexed {
+		return nil
+	}
+
+	var all []*model.Work
+	if err := tx.All(&all); err != nil {
+		return err
 	}
-	for _, fTags := range f.Tags {
-		if fTags != "" && !helpers.AInB(fTags, strings.Join(w.Tags, " ")) {
-			return false
+	helpers.LogDebug(fmt.Sprintf("indexing tags of %d items", len(all)), "update db - bolt")
+	for _, wl := range all {
+		if err := indexTags(btx, wl); err != nil {
+			return err
 		}
 	}
-	return true
+	return tx.Set(metaBucket, tagsIndexedKey, true)
 }
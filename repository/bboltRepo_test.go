@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/asdine/storm/v3"
+	"github.com/stretchr/testify/assert"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestBoltRepositoryLegacyDurations(t *testing.T) {
+	var tests = []struct {
+		name        string
+		minutes     int
+		expDuration int
+	}{
+		{
+			name:        "Recorded in minutes",
+			minutes:     1,
+			expDuration: 90,
+		}, {
+			name:        "Recorded in hours",
+			minutes:     60,
+			expDuration: 90 * 60,
+		}, {
+			name:        "Unset is minutes",
+			minutes:     0,
+			expDuration: 90,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "worklog.db")
+			r := NewBBoltRepo(path)
+			wl := model.NewWork("Legacy", "", "Alice", 90, nil, time.Now())
+			assert.Nil(t, r.Save(wl))
+
+			// Recorded before durations were migrated
+			db, err := storm.Open(path)
+			assert.Nil(t, err)
+			assert.Nil(t, db.Set(metaBucket, durationsMigratedKey, false))
+			assert.Nil(t, db.Close())
+
+			legacy, ok := r.(LegacyDurationRepository)
+			assert.True(t, ok)
+			legacy.SetLegacyDurationMinutes(testItem.minutes)
+			assert.Nil(t, r.Init())
+			// Only converted once
+			assert.Nil(t, r.Init())
+
+			found, err := r.GetByID(wl.ID, &model.Work{})
+			assert.Nil(t, err)
+			assert.Equal(t, testItem.expDuration, found.Duration)
+			revisions, err := r.GetRevisions(wl.ID)
+			assert.Nil(t, err)
+			assert.Len(t, revisions, 1)
+			assert.Equal(t, testItem.expDuration, revisions[0].Duration)
+		})
+	}
+}
+
+func TestBoltRepositoryLegacyDurationsAfterMinutes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worklog.db")
+	db, err := storm.Open(path)
+	assert.Nil(t, err)
+	wl := model.NewWork("Legacy", "", "Alice", 90, nil, time.Now())
+	assert.Nil(t, db.Save(wl))
+	assert.Nil(t, db.Close())
+
+	r := NewBBoltRepo(path)
+	legacy := r.(LegacyDurationRepository)
+	assert.Nil(t, r.Init())
+	legacy.SetLegacyDurationMinutes(60)
+	assert.Nil(t, r.Init())
+	assert.Nil(t, r.Init())
+
+	found, err := r.GetByID(wl.ID, &model.Work{})
+	assert.Nil(t, err)
+	assert.Equal(t, 90*60, found.Duration, "converted once the unit is given")
+}
+
+func TestBoltRepositoryTagIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worklog.db")
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+
+	// Saved before the tag index existed
+	db, err := storm.Open(path)
+	assert.Nil(t, err)
+	before := model.NewWork("Before", "", "Alice", 30, []string{"Golang"}, when)
+	assert.Nil(t, db.Save(before))
+	assert.Nil(t, db.Close())
+
+	r := NewBBoltRepo(path)
+	found := between(t, r, time.Time{}, &model.Work{Tags: []string{"golang"}})
+	assert.Len(t, found, 1, "unindexed work is still found")
+
+	after := model.NewWork("After", "", "Alice", 30, []string{"go"}, when.Add(24*time.Hour))
+	assert.Nil(t, r.Save(after))
+
+	for _, testItem := range []struct {
+		tag   string
+		start time.Time
+		exp   []string
+	}{
+		{tag: "go", exp: []string{"After"}},
+		{tag: "GO*", exp: []string{"Before", "After"}},
+		{tag: "go*", start: when.Add(time.Hour), exp: []string{"After"}},
+		{tag: "lang", exp: []string{}},
+	} {
+		titles := []string{}
+		for _, wl := range between(t, r, testItem.start, &model.Work{Tags: []string{testItem.tag}}) {
+			titles = append(titles, wl.Title)
+		}
+		assert.Equal(t, testItem.exp, titles, testItem.tag)
+	}
+
+	// Purged work is removed from the index
+	assert.Nil(t, r.Delete(after.ID))
+	db, err = storm.Open(path)
+	assert.Nil(t, err)
+	defer func() {
+		assert.Nil(t, db.Close())
+	}()
+	assert.Nil(t, db.Bolt.View(func(btx *bolt.Tx) error {
+		return btx.Bucket([]byte(tagBucket)).ForEach(func(k, _ []byte) error {
+			_, _, id, _ := parseTagKey(k)
+			assert.NotEqual(t, after.ID, id)
+			return nil
+		})
+	}))
+}
+
+func TestScanTag(t *testing.T) {
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC).Unix()
+	day := int64(24 * 60 * 60)
+	keys := [][]byte{
+		tagKey("go", when-day, "go-before"),
+		tagKey("go", when, "go-during"),
+		tagKey("go", when+day, "go-after"),
+		tagKey("golang", when-day, "golang-before"),
+		tagKey("golang", when, "golang-during"),
+		tagKey("golang", when+day, "golang-after"),
+		tagKey("gopher", when, "gopher-during"),
+		tagKey("rust", when, "rust-during"),
+		// Work with both tags
+		tagKey("rust", when, "golang-during"),
+		// Only ever before, so skipped entirely
+		tagKey("gone", when-2*day, "gone-before"),
+	}
+
+	var tests = []struct {
+		name   string
+		filter string
+		start  int64
+		end    int64
+		expIDs []string
+	}{
+		{
+			name:   "Exact tag",
+			filter: "go",
+			start:  when - 2*day,
+			end:    when + 2*day,
+			expIDs: []string{"go-before", "go-during", "go-after"},
+		}, {
+			name:   "Exact tag within dates",
+			filter: "GO",
+			start:  when,
+			end:    when + 1,
+			expIDs: []string{"go-during"},
+		}, {
+			name:   "Prefix spanning tags within dates",
+			filter: "go*",
+			start:  when,
+			end:    when + 1,
+			expIDs: []string{"go-during", "golang-during", "gopher-during"},
+		}, {
+			name:   "Prefix spanning tags after the dates",
+			filter: "go*",
+			start:  when + day,
+			end:    when + 2*day,
+			expIDs: []string{"go-after", "golang-after"},
+		}, {
+			name:   "Prefix spanning tags before the dates",
+			filter: "gol*",
+			start:  when - 2*day,
+			end:    when,
+			expIDs: []string{"golang-before"},
+		}, {
+			name:   "Only whole tags without a prefix",
+			filter: "gol",
+			start:  when - 2*day,
+			end:    when + 2*day,
+			expIDs: []string{},
+		}, {
+			name:   "Past the dates",
+			filter: "go*",
+			start:  when + 2*day,
+			end:    when + 3*day,
+			expIDs: []string{},
+		},
+	}
+
+	db, err := bolt.Open(filepath.Join(t.TempDir(), "index.db"), 0600, nil)
+	assert.Nil(t, err)
+	defer func() {
+		assert.Nil(t, db.Close())
+	}()
+	assert.Nil(t, db.Update(func(btx *bolt.Tx) error {
+		b, err := btx.CreateBucket([]byte(tagBucket))
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := b.Put(key, []byte{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			assert.Nil(t, db.View(func(btx *bolt.Tx) error {
+				ids := scanTag(btx.Bucket([]byte(tagBucket)).Cursor(), testItem.filter, testItem.start, testItem.end)
+				assert.Equal(t, testItem.expIDs, ids)
+				return nil
+			}))
+		})
+	}
+
+	t.Run("Tagged with every tag", func(t *testing.T) {
+		assert.Nil(t, db.View(func(btx *bolt.Tx) error {
+			b := btx.Bucket([]byte(tagBucket))
+			assert.Equal(t, []string{"go-during", "golang-during", "gopher-during"},
+				taggedIDs(b, []string{"go*", ""}, when, when+1))
+			assert.Equal(t, []string{"golang-during"}, taggedIDs(b, []string{"RUST", "go*"}, when, when+1))
+			assert.Equal(t, []string{}, taggedIDs(b, []string{"go", "rust"}, when, when+1))
+			assert.Equal(t, []string{}, taggedIDs(b, []string{"rust"}, when+1, when+2))
+			assert.Equal(t, []string{}, taggedIDs(nil, []string{"go"}, when, when+1))
+			return nil
+		}))
+	})
+}
@@ -0,0 +1,729 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	gitFileExtension = ".yml"
+	gitTimerFileName = "worklog-timer.yml"
+	gitRemote        = "origin"
+	gitBranch        = "main"
+)
+
+type gitRepo struct {
+	dir  string
+	lock sync.Mutex
+}
+
+// NewGitRepo initializes the repo with the given directory, being a
+// git working tree with a file for each worklog. Every change is
+// committed, so earlier revisions are kept in the history of the file.
+func NewGitRepo(dir string) WorklogRepository {
+	return &gitRepo{dir: dir}
+}
+
+func (r *gitRepo) Init() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.init()
+}
+
+// Close has nothing to close, as every change is already committed
+func (*gitRepo) Close() error {
+	return nil
+}
+
+func (r *gitRepo) Save(wl *model.Work) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.init(); err != nil {
+		return err
+	}
+
+	helpers.LogDebug("Saving file...", "save model - git")
+	saved := *wl
+	saved.WhenQueryEpoch = saved.When.Unix()
+	fileName, err := r.fileName(wl.ID)
+	if err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	current, err := r.read(fileName)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := r.commit(&saved); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving revision: %s", err.Error()), "save model error - git")
+		return err
+	}
+	// Older revisions are only kept in the history
+	if current != nil && current.Revision > wl.Revision {
+		if err := r.commit(current); err != nil {
+			helpers.LogError(fmt.Sprintf("Error restoring revision: %s", err.Error()), "save model error - git")
+			return err
+		}
+	}
+
+	helpers.LogDebug("Saved file", "save model successful - git")
+	return nil
+}
+
+func (r *gitRepo) Delete(id string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	helpers.LogDebug("Deleting worklog...", "delete model - git")
+	fileName, err := r.fileName(id)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filepath.Join(r.dir, fileName)); err != nil {
+		return errors.New(e.RepoDeleteNotFound)
+	}
+	if _, err := r.git("rm", "-q", "--", fileName); err != nil {
+		return fmt.Errorf("%s %s. %s", e.RepoDeleteFile, fileName, err.Error())
+	}
+	if _, err := r.gitAs("", "commit", "-q", "-m", fmt.Sprintf("Delete %s", id)); err != nil {
+		helpers.LogError(fmt.Sprintf("Error deleting worklog: %s", err.Error()), "delete model error - git")
+		return err
+	}
+
+	helpers.LogDebug("Deleted worklog", "delete model successful - git")
+	return nil
+}
+
+func (r *gitRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	all, err := r.latest()
+	if err != nil {
+		return nil, err
+	}
+	found := []*model.Work{}
+	for _, wl := range all {
+		if wl.WhenQueryEpoch >= startDate.Unix() &&
+			wl.WhenQueryEpoch < endDate.Unix() &&
+			matches(wl) {
+			found = append(found, wl)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].WhenQueryEpoch != found[j].WhenQueryEpoch {
+			return found[i].WhenQueryEpoch < found[j].WhenQueryEpoch
+		}
+		return found[i].ID < found[j].ID
+	})
+	return found, nil
+}
+
+func (r *gitRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	fileName, err := r.matchFile(ID)
+	if err != nil || fileName == "" {
+		return nil, err
+	}
+	found, err := r.read(fileName)
+	if err != nil || !matches(found) {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (r *gitRepo) GetRevisions(ID string) ([]*model.Work, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	fileName, err := r.matchFile(ID)
+	if err != nil {
+		return nil, err
+	} else if fileName == "" {
+		return []*model.Work{}, nil
+	}
+	return r.history("HEAD", fileName)
+}
+
+func (r *gitRepo) GetAll() ([]*model.Work, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	fileNames, err := r.fileNames()
+	if err != nil {
+		return nil, err
+	}
+	all := []*model.Work{}
+	if len(fileNames) == 0 {
+		return all, nil
+	}
+	histories, err := r.histories("HEAD", fileNames...)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileName := range fileNames {
+		all = append(all, histories[fileName]...)
+	}
+	return all, nil
+}
+
+func (r *gitRepo) SaveTimer(wl *model.Work) error {
+	if err := r.Init(); err != nil {
+		return err
+	}
+	var b bytes.Buffer
+	if err := wl.WriteYAML(&b); err != nil {
+		return err
+	}
+	// Kept within the git directory, so it is never committed
+	if err := writeFileAtomically(filepath.Join(r.dir, ".git", gitTimerFileName), b.Bytes()); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving timer: %s", err.Error()), "save timer error - git")
+		return err
+	}
+	return nil
+}
+
+func (r *gitRepo) GetTimer() (*model.Work, error) {
+	b, err := os.ReadFile(filepath.Join(r.dir, ".git", gitTimerFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+	}
+	wl, err := model.ReadYAML(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+	}
+	wl.Sanitize()
+	return wl, nil
+}
+
+func (r *gitRepo) DeleteTimer() error {
+	err := os.Remove(filepath.Join(r.dir, ".git", gitTimerFileName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		helpers.LogError(fmt.Sprintf("Error deleting timer: %s", err.Error()), "delete timer error - git")
+		return err
+	}
+	return nil
+}
+
+// Sync merges the worklogs of the remote into the working tree, then
+// pushes them back. When the same worklog has changed in both, the
+// highest revision is kept, unless any revision differs between them.
+// If provided, the remote is first set to the url.
+func (r *gitRepo) Sync(remoteURL string) (*model.SyncSummary, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.init(); err != nil {
+		return nil, err
+	}
+	if err := r.setRemote(remoteURL); err != nil {
+		return nil, err
+	}
+
+	helpers.LogDebug("Fetching worklogs...", "sync - git")
+	if _, err := r.git("fetch", "-q", gitRemote); err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoSync, err.Error())
+	}
+	summary := &model.SyncSummary{}
+	remoteBranch := gitRemote + "/" + gitBranch
+	if _, err := r.git("rev-parse", "-q", "--verify", remoteBranch); err == nil {
+		if err := r.merge(remoteBranch, summary); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := r.git("rev-parse", "-q", "--verify", "HEAD"); err != nil {
+		helpers.LogDebug("Nothing to push", "sync - git")
+		return summary, nil
+	}
+	pushFrom, aheadOf := "", "HEAD"
+	if _, err := r.git("rev-parse", "-q", "--verify", remoteBranch); err == nil {
+		pushFrom, aheadOf = remoteBranch, remoteBranch+"..HEAD"
+	}
+	ahead, err := r.git("rev-list", "--count", aheadOf)
+	if err != nil {
+		return nil, err
+	}
+	if summary.Pushed, err = r.newRevisions(pushFrom, "HEAD"); err != nil {
+		return nil, err
+	}
+	// Commits without new revisions, such as saving work unchanged,
+	// are still pushed to keep the branches the same
+	if ahead != "0" {
+		helpers.LogDebug("Pushing worklogs...", "sync - git")
+		if _, err := r.git("push", "-q", "-u", gitRemote, "HEAD:"+gitBranch); err != nil {
+			return nil, fmt.Errorf("%s. %s", e.RepoSync, err.Error())
+		}
+	}
+	return summary, nil
+}
+
+// merge the remote branch into the working tree. Worklogs changed
+// in both are resolved by their revision.
+func (r *gitRepo) merge(remoteBranch string, summary *model.SyncSummary) error {
+	var err error
+	if _, headErr := r.git("rev-parse", "-q", "--verify", "HEAD"); headErr != nil {
+		// Nothing has been saved yet, so all worklogs are the remote's
+		if summary.Pulled, err = r.newRevisions("", remoteBranch); err != nil {
+			return err
+		}
+		_, err = r.git("reset", "-q", "--hard", remoteBranch)
+		return err
+	}
+
+	behind, err := r.git("rev-list", "--count", "HEAD.."+remoteBranch)
+	if err != nil {
+		return err
+	} else if behind == "0" {
+		return nil
+	}
+	if summary.Pulled, err = r.newRevisions("HEAD", remoteBranch); err != nil {
+		return err
+	}
+
+	helpers.LogDebug(fmt.Sprintf("Merging %s commits...", behind), "sync - git")
+	// Working trees created separately have unrelated histories
+	_, mergeErr := r.gitAs("", "merge", "-q", "--no-edit", "--allow-unrelated-histories", remoteBranch)
+	if mergeErr == nil {
+		return nil
+	}
+	conflicted, err := r.git("diff", "--name-only", "--diff-filter=U")
+	if err != nil || conflicted == "" {
+		_, _ = r.git("merge", "--abort")
+		return fmt.Errorf("%s. %s", e.RepoSync, mergeErr.Error())
+	}
+
+	conflicts := []string{}
+	for _, fileName := range strings.Split(conflicted, "\n") {
+		resolved, err := r.resolve(fileName)
+		if err != nil {
+			_, _ = r.git("merge", "--abort")
+			return err
+		} else if !resolved {
+			conflicts = append(conflicts, strings.TrimSuffix(fileName, gitFileExtension))
+			continue
+		}
+		summary.Resolved++
+	}
+	if len(conflicts) != 0 {
+		_, _ = r.git("merge", "--abort")
+		return fmt.Errorf("%s: %s", e.RepoSyncConflict, strings.Join(conflicts, ", "))
+	}
+	_, err = r.gitAs("", "commit", "-q", "--no-edit")
+	return err
+}
+
+// resolve keeps the highest revision of a worklog changed in both the
+// working tree and remote. A worklog deleted in either, or with any
+// revision in both histories differing, can't be resolved.
+func (r *gitRepo) resolve(fileName string) (bool, error) {
+	if _, err := r.git("show", ":2:"+fileName); err != nil {
+		return false, nil
+	} else if _, err := r.git("show", ":3:"+fileName); err != nil {
+		return false, nil
+	}
+	ours, err := r.history("HEAD", fileName)
+	if err != nil {
+		return false, err
+	}
+	theirs, err := r.history("MERGE_HEAD", fileName)
+	if err != nil {
+		return false, err
+	}
+
+	// Revisions up to where they diverged are the same in both
+	for _, our := range ours {
+		for _, their := range theirs {
+			if our.Revision == their.Revision &&
+				len(model.NewRevisionDiff(our, their).Changes) != 0 {
+				return false, nil
+			}
+		}
+	}
+
+	stage := "--ours"
+	if theirs[len(theirs)-1].Revision > ours[len(ours)-1].Revision {
+		stage = "--theirs"
+	}
+	if _, err := r.git("checkout", stage, "--", fileName); err != nil {
+		return false, err
+	}
+	_, err = r.git("add", "--", fileName)
+	return err == nil, err
+}
+
+// newRevisions the number of worklog revisions in to which aren't in from,
+// from the worklog files changed since they diverged. Without from, or when
+// their histories are unrelated, every revision in to is new.
+func (r *gitRepo) newRevisions(from, to string) (int, error) {
+	base := ""
+	if from != "" {
+		base, _ = r.git("merge-base", from, to)
+	}
+	var changed string
+	var err error
+	if base == "" {
+		changed, err = r.git("ls-tree", "--name-only", to)
+	} else {
+		changed, err = r.git("diff", "--name-only", base, to)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, fileName := range strings.Fields(changed) {
+		if !strings.HasSuffix(fileName, gitFileExtension) {
+			continue
+		}
+		latest := r.revisionAt(to, fileName)
+		if latest == 0 {
+			// Deleted entirely, which is a single change
+			count++
+			continue
+		}
+		count += max(latest-r.revisionAt(base, fileName), 0)
+	}
+	return count, nil
+}
+
+// revisionAt the revision of the worklog in the file at ref,
+// or 0 if it isn't there
+func (r *gitRepo) revisionAt(ref, fileName string) int {
+	if ref == "" {
+		return 0
+	}
+	content, err := r.git("show", ref+":"+fileName)
+	if err != nil {
+		return 0
+	}
+	wl, err := model.ReadYAML([]byte(content))
+	if err != nil {
+		return 0
+	}
+	return wl.Revision
+}
+
+// setRemote points the remote at the url, unless no url is provided,
+// in which case a remote must already exist
+func (r *gitRepo) setRemote(url string) error {
+	current, err := r.git("remote", "get-url", gitRemote)
+	if url == "" && err != nil {
+		return errors.New(e.RepoSyncNoRemote)
+	} else if url == "" || url == current {
+		return nil
+	} else if err != nil {
+		_, err = r.git("remote", "add", gitRemote, url)
+		return err
+	}
+	_, err = r.git("remote", "set-url", gitRemote, url)
+	return err
+}
+
+// init creates the working tree if it doesn't already exist.
+// Requires the lock to be held.
+func (r *gitRepo) init() error {
+	if _, err := os.Stat(filepath.Join(r.dir, ".git")); err == nil {
+		return nil
+	}
+	helpers.LogDebug(fmt.Sprintf("Creating git repository at %s", r.dir), "init - git")
+	// #nosec G204 -- Arguments are passed to git directly, not through a shell
+	if out, err := exec.Command("git", "init", "-q", "-b", gitBranch, r.dir).CombinedOutput(); err != nil {
+		return fmt.Errorf("%s %s. %s", e.RepoCreateDirectory, r.dir, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// commit writes the work to its file, and commits it
+// with a message of its title and revision
+func (r *gitRepo) commit(wl *model.Work) error {
+	var b bytes.Buffer
+	if err := wl.WriteYAML(&b); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	fileName, err := r.fileName(wl.ID)
+	if err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, fileName), b.Bytes(), 0600); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	if _, err := r.git("add", "--", fileName); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	// Saving a revision unchanged has nothing to commit
+	if _, err := r.git("diff", "--cached", "--quiet"); err == nil {
+		return nil
+	}
+	message := fmt.Sprintf("%s (revision %d)", wl.Title, wl.Revision)
+	if _, err := r.gitAs(wl.Author, "commit", "-q", "-m", message); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	return nil
+}
+
+// latest the latest revision of every worklog
+func (r *gitRepo) latest() ([]*model.Work, error) {
+	fileNames, err := r.fileNames()
+	if err != nil {
+		return nil, err
+	}
+	all := make([]*model.Work, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		wl, err := r.read(fileName)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, wl)
+	}
+	return all, nil
+}
+
+// history every revision of the worklog in the file, from the commits
+// of ref changing it
+func (r *gitRepo) history(ref, fileName string) ([]*model.Work, error) {
+	histories, err := r.histories(ref, fileName)
+	if err != nil {
+		return nil, err
+	}
+	return histories[fileName], nil
+}
+
+// histories every revision of the worklogs in the files, from the commits
+// of ref changing them. The log and the content of every commit are each
+// read by a single git process, however many files or commits there are.
+// Where a revision was committed more than once, such as from being edited
+// without a new revision, the last is used.
+func (r *gitRepo) histories(ref string, fileNames ...string) (map[string][]*model.Work, error) {
+	wanted := make(map[string]bool, len(fileNames))
+	for _, fileName := range fileNames {
+		wanted[fileName] = true
+	}
+	log, err := r.git("log", "--format=%H", "--raw", "--no-abbrev", "--no-renames",
+		"--diff-merges=first-parent", ref)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+	}
+
+	// The log is newest first, with the files changed by each commit as
+	// ":<old mode> <new mode> <old blob> <new blob> <status>\t<file>"
+	type change struct {
+		fileName string
+		blob     string
+	}
+	changes := []change{}
+	blobs := []string{}
+	for _, line := range strings.Split(log, "\n") {
+		meta, fileName, ok := strings.Cut(line, "\t")
+		fields := strings.Fields(meta)
+		if !ok || !strings.HasPrefix(line, ":") || len(fields) != 5 ||
+			!wanted[fileName] || strings.HasPrefix(fields[4], "D") {
+			continue
+		}
+		changes = append(changes, change{fileName: fileName, blob: fields[3]})
+		blobs = append(blobs, fields[3])
+	}
+	contents, err := r.catFiles(blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	byRevision := make(map[string]map[int]*model.Work, len(fileNames))
+	for _, c := range changes {
+		wl, err := model.ReadYAML(contents[c.blob])
+		if err != nil {
+			return nil, fmt.Errorf("%s %s. %s", e.RepoGetFilesRead, c.fileName, err.Error())
+		}
+		if byRevision[c.fileName] == nil {
+			byRevision[c.fileName] = make(map[int]*model.Work)
+		}
+		if _, ok := byRevision[c.fileName][wl.Revision]; !ok {
+			wl.Sanitize()
+			byRevision[c.fileName][wl.Revision] = wl
+		}
+	}
+
+	histories := make(map[string][]*model.Work, len(byRevision))
+	for fileName, revisions := range byRevision {
+		for _, wl := range revisions {
+			histories[fileName] = append(histories[fileName], wl)
+		}
+		sort.Slice(histories[fileName], func(i, j int) bool {
+			return histories[fileName][i].Revision < histories[fileName][j].Revision
+		})
+	}
+	return histories, nil
+}
+
+// catFiles the content of each blob, read by a single git process
+func (r *gitRepo) catFiles(blobs []string) (map[string][]byte, error) {
+	contents := make(map[string][]byte, len(blobs))
+	if len(blobs) == 0 {
+		return contents, nil
+	}
+	out, err := r.gitWithInput(strings.NewReader(strings.Join(blobs, "\n")+"\n"), "cat-file", "--batch")
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+	}
+
+	// Each blob is output as "<blob> blob <size>\n<content>\n"
+	reader := bufio.NewReader(bytes.NewReader(out))
+	for range blobs {
+		header, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, strings.TrimSpace(header))
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+		}
+		content := make([]byte, size+1)
+		if _, err := io.ReadFull(reader, content); err != nil {
+			return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+		}
+		contents[fields[0]] = content[:size]
+	}
+	return contents, nil
+}
+
+// read the worklog in the file of the working tree
+func (r *gitRepo) read(fileName string) (*model.Work, error) {
+	b, err := os.ReadFile(filepath.Join(r.dir, filepath.Clean(fileName)))
+	if err != nil {
+		return nil, err
+	}
+	wl, err := model.ReadYAML(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s. %s", e.RepoGetFilesRead, fileName, err.Error())
+	}
+	wl.Sanitize()
+	return wl, nil
+}
+
+// fileNames the file of every worklog in the working tree, sorted by ID
+func (r *gitRepo) fileNames() ([]string, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, errors.New(e.RepoGetFilesRead)
+	}
+	fileNames := []string{}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), gitFileExtension) {
+			fileNames = append(fileNames, entry.Name())
+		}
+	}
+	sort.Strings(fileNames)
+	return fileNames, nil
+}
+
+// matchFile the file of the worklog with an ID matching,
+// the same as the bolt repository
+func (r *gitRepo) matchFile(ID string) (string, error) {
+	re, err := regexp.Compile(helpers.RegexCaseInsensitive + ID)
+	if err != nil {
+		return "", err
+	}
+	fileNames, err := r.fileNames()
+	if err != nil {
+		return "", err
+	}
+	found := ""
+	for _, fileName := range fileNames {
+		if !re.MatchString(strings.TrimSuffix(fileName, gitFileExtension)) {
+			continue
+		} else if found != "" {
+			return "", errors.New(e.RepoGetSingleFileAmbiguous)
+		}
+		found = fileName
+	}
+	return found, nil
+}
+
+// fileName the file of the worklog within the working tree, refusing
+// IDs which would be outside of it
+func (r *gitRepo) fileName(id string) (string, error) {
+	fileName := id + gitFileExtension
+	if _, err := filePath(r.dir, fileName); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
+
+// gitAs runs git as the author if the working tree doesn't
+// have a user configured, as required to commit
+func (r *gitRepo) gitAs(author string, args ...string) (string, error) {
+	if name, _ := r.git("config", "user.name"); name == "" {
+		if author == "" {
+			author = viper.GetString("default.author")
+		}
+		if author == "" {
+			author = "worklog"
+		}
+		args = append([]string{"-c", "user.name=" + author, "-c", "user.email=worklog@localhost"}, args...)
+	} else if email, _ := r.git("config", "user.email"); email == "" {
+		args = append([]string{"-c", "user.email=worklog@localhost"}, args...)
+	}
+	return r.git(args...)
+}
+
+// git runs git against the working tree, returning its trimmed output
+func (r *gitRepo) git(args ...string) (string, error) {
+	out, err := r.gitWithInput(nil, args...)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// gitWithInput runs git in the working tree with the input,
+// returning its output unchanged
+func (r *gitRepo) gitWithInput(input io.Reader, args ...string) ([]byte, error) {
+	// #nosec G204 -- Arguments are passed to git directly, not through a shell
+	cmd := exec.Command("git", append([]string{"-C", r.dir}, args...)...)
+	cmd.Stdin = input
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
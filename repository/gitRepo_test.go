@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitRepositoryHistory(t *testing.T) {
+	r := NewGitRepo(filepath.Join(t.TempDir(), "git")).(*gitRepo)
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	first := model.NewWork("First", "", "Alice", 30, []string{"log"}, when)
+	second := model.NewWork("Second", "", "Bob", 15, nil, when.Add(time.Hour))
+	for _, wl := range []*model.Work{first, second} {
+		assert.Nil(t, r.Save(wl))
+	}
+	edit(t, r, first.ID, model.Work{Title: "First edited"})
+	edited := edit(t, r, first.ID, model.Work{Duration: 45})
+
+	commits, err := r.git("rev-list", "--count", "HEAD")
+	assert.Nil(t, err)
+	assert.Nil(t, r.Save(edited))
+	unchanged, err := r.git("rev-list", "--count", "HEAD")
+	assert.Nil(t, err)
+	assert.Equal(t, commits, unchanged, "saving unchanged work has nothing to commit")
+
+	all, err := r.GetAll()
+	assert.Nil(t, err)
+	assert.Len(t, all, 4)
+	revisions, err := r.GetRevisions(first.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 3)
+	for i, exp := range []struct {
+		title    string
+		duration int
+	}{
+		{title: "First", duration: 30},
+		{title: "First edited", duration: 30},
+		{title: "First edited", duration: 45},
+	} {
+		assert.Equal(t, i+1, revisions[i].Revision)
+		assert.Equal(t, exp.title, revisions[i].Title)
+		assert.Equal(t, exp.duration, revisions[i].Duration)
+	}
+}
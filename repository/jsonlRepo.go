@@ -0,0 +1,479 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+const (
+	jsonlIndexSuffix = ".index"
+	jsonlTimerSuffix = ".timer"
+)
+
+type jsonlRepo struct {
+	path string
+	lock sync.Mutex
+}
+
+// jsonlEntry is a line of the log, either saving a revision
+// of work or deleting every revision of it
+type jsonlEntry struct {
+	Work   *model.Work `json:"work,omitempty"`
+	Delete string      `json:"delete,omitempty"`
+}
+
+// jsonlIndex is where each revision of work is within the log. It is
+// only used while the log is the size and age it was when indexed,
+// otherwise it is rebuilt by replaying the log.
+type jsonlIndex struct {
+	Size     int64                       `json:"size"`
+	ModTime  int64                       `json:"modTime"`
+	Worklogs map[string]*jsonlIndexEntry `json:"worklogs"`
+}
+
+type jsonlIndexEntry struct {
+	WhenQueryEpoch int64             `json:"whenEpoch"`
+	Latest         int               `json:"latest"`
+	Revisions      map[int]jsonlLine `json:"revisions"`
+}
+
+type jsonlLine struct {
+	Offset int64 `json:"offset"`
+	Length int   `json:"length"`
+}
+
+// NewJSONLRepo initializes the repo with the given filepath. Every
+// change is appended to the file as a line of JSON, with an index
+// of the file stored alongside it.
+func NewJSONLRepo(path string) WorklogRepository {
+	return &jsonlRepo{path: path}
+}
+
+func (r *jsonlRepo) Init() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", err.Error()), "read db error - jsonl")
+		return fmt.Errorf("%s. %s", e.RepoCreateFile, err.Error())
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	_, err = r.readIndex()
+	return err
+}
+
+// Close has nothing to close, as the file is only appended to while saving
+func (*jsonlRepo) Close() error {
+	return nil
+}
+
+func (r *jsonlRepo) Save(wl *model.Work) error {
+	saved := *wl
+	saved.WhenQueryEpoch = saved.When.Unix()
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	helpers.LogDebug("Saving file...", "save model - jsonl")
+	if err := r.append(jsonlEntry{Work: &saved}); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving revision: %s", err.Error()), "save model error - jsonl")
+		return err
+	}
+	helpers.LogDebug("Saved file", "save model successful - jsonl")
+	return nil
+}
+
+func (r *jsonlRepo) Delete(id string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	index, err := r.readIndex()
+	if err != nil {
+		return err
+	} else if _, ok := index.Worklogs[id]; !ok {
+		return errors.New(e.RepoDeleteNotFound)
+	}
+
+	helpers.LogDebug("Deleting worklog...", "delete model - jsonl")
+	if err := r.append(jsonlEntry{Delete: id}); err != nil {
+		helpers.LogError(fmt.Sprintf("Error deleting worklog: %s", err.Error()), "delete model error - jsonl")
+		return err
+	}
+	helpers.LogDebug("Deleted worklog", "delete model successful - jsonl")
+	return nil
+}
+
+func (r *jsonlRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	index, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	lines := []jsonlLine{}
+	for _, entry := range index.Worklogs {
+		if entry.WhenQueryEpoch >= startDate.Unix() && entry.WhenQueryEpoch < endDate.Unix() {
+			lines = append(lines, entry.Revisions[entry.Latest])
+		}
+	}
+	all, err := r.readLines(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	found := []*model.Work{}
+	for _, wl := range all {
+		if matches(wl) {
+			found = append(found, wl)
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].WhenQueryEpoch != found[j].WhenQueryEpoch {
+			return found[i].WhenQueryEpoch < found[j].WhenQueryEpoch
+		}
+		return found[i].ID < found[j].ID
+	})
+	return found, nil
+}
+
+func (r *jsonlRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	index, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	id, err := index.matchID(ID)
+	if err != nil || id == "" {
+		return nil, err
+	}
+	entry := index.Worklogs[id]
+	found, err := r.readLines([]jsonlLine{entry.Revisions[entry.Latest]})
+	if err != nil || !matches(found[0]) {
+		return nil, err
+	}
+	return found[0], nil
+}
+
+func (r *jsonlRepo) GetRevisions(ID string) ([]*model.Work, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	index, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	id, err := index.matchID(ID)
+	if err != nil {
+		return nil, err
+	} else if id == "" {
+		return []*model.Work{}, nil
+	}
+	return r.readLines(index.revisionLines(id))
+}
+
+func (r *jsonlRepo) GetAll() ([]*model.Work, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	index, err := r.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(index.Worklogs))
+	for id := range index.Worklogs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	lines := []jsonlLine{}
+	for _, id := range ids {
+		lines = append(lines, index.revisionLines(id)...)
+	}
+	return r.readLines(lines)
+}
+
+func (r *jsonlRepo) SaveTimer(wl *model.Work) error {
+	b, err := json.Marshal(wl)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomically(r.path+jsonlTimerSuffix, b); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving timer: %s", err.Error()), "save timer error - jsonl")
+		return err
+	}
+	return nil
+}
+
+func (r *jsonlRepo) GetTimer() (*model.Work, error) {
+	b, err := os.ReadFile(r.path + jsonlTimerSuffix)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+	}
+	var wl model.Work
+	if err := json.Unmarshal(b, &wl); err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+	}
+	wl.Sanitize()
+	return &wl, nil
+}
+
+func (r *jsonlRepo) DeleteTimer() error {
+	err := os.Remove(r.path + jsonlTimerSuffix)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		helpers.LogError(fmt.Sprintf("Error deleting timer: %s", err.Error()), "delete timer error - jsonl")
+		return err
+	}
+	return nil
+}
+
+// append writes the entry as a line at the end of the log, and adds
+// it to the index. Requires the lock to be held.
+func (r *jsonlRepo) append(entry jsonlEntry) error {
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	// Creating the log on the first save, so the index is of an empty log
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("%s. %s", e.RepoCreateFile, err.Error())
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+	index, err := r.readIndex()
+	if err != nil {
+		return err
+	}
+
+	offset := index.Size
+	// The log may have been edited by hand without a final new line
+	if offset > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, offset-1); err != nil {
+			return err
+		} else if last[0] != '\n' {
+			b = append([]byte{'\n'}, b...)
+			offset++
+		}
+	}
+	if _, err := file.WriteAt(append(b, '\n'), index.Size); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+
+	index.apply(entry, jsonlLine{Offset: offset, Length: int(index.Size + int64(len(b)) + 1 - offset)})
+	return r.writeIndex(index)
+}
+
+// readIndex reads the index of the log, rebuilding it if the log has
+// changed since it was written, such as from being merged. Requires the
+// lock to be held.
+func (r *jsonlRepo) readIndex() (*jsonlIndex, error) {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return nil, errors.New(e.RepoGetFilesRead)
+	}
+
+	// #nosec G304 -- The index is always alongside the log
+	if b, err := os.ReadFile(r.path + jsonlIndexSuffix); err == nil {
+		var index jsonlIndex
+		if json.Unmarshal(b, &index) == nil &&
+			index.Size == info.Size() &&
+			index.ModTime == info.ModTime().UnixNano() &&
+			index.Worklogs != nil {
+			return &index, nil
+		}
+	}
+
+	helpers.LogDebug("Rebuilding index...", "index - jsonl")
+	index, err := r.replay()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.writeIndex(index); err != nil {
+		return nil, err
+	}
+	helpers.LogDebug(fmt.Sprintf("Rebuilt index of %d worklogs", len(index.Worklogs)), "index - jsonl")
+	return index, nil
+}
+
+// replay reads every line of the log to build its index
+func (r *jsonlRepo) replay() (*jsonlIndex, error) {
+	// #nosec G304 -- The path is provided by the user for their worklogs
+	file, err := os.Open(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	index := &jsonlIndex{Worklogs: make(map[string]*jsonlIndexEntry)}
+	reader := bufio.NewReader(file)
+	for number := 1; ; number++ {
+		line, readErr := reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, readErr.Error())
+		}
+		if len(bytes.TrimSpace(line)) != 0 {
+			var entry jsonlEntry
+			if err := json.Unmarshal(line, &entry); err != nil ||
+				(entry.Work == nil && entry.Delete == "") {
+				return nil, fmt.Errorf("%s %s, line %d is not a worklog", e.RepoGetFilesRead, r.path, number)
+			}
+			index.apply(entry, jsonlLine{Offset: index.Size, Length: len(line)})
+		}
+		index.Size += int64(len(line))
+		if readErr == io.EOF {
+			break
+		}
+	}
+	return index, nil
+}
+
+// writeIndex stores the index of the log as it currently is
+func (r *jsonlRepo) writeIndex(index *jsonlIndex) error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return err
+	}
+	index.Size = info.Size()
+	index.ModTime = info.ModTime().UnixNano()
+	b, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomically(r.path+jsonlIndexSuffix, b)
+}
+
+// readLines reads the work from each line of the log
+func (r *jsonlRepo) readLines(lines []jsonlLine) ([]*model.Work, error) {
+	// #nosec G304 -- The path is provided by the user for their worklogs
+	file, err := os.Open(r.path)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	found := make([]*model.Work, 0, len(lines))
+	for _, line := range lines {
+		b := make([]byte, line.Length)
+		if _, err := file.ReadAt(b, line.Offset); err != nil {
+			return nil, fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+		}
+		var entry jsonlEntry
+		if err := json.Unmarshal(b, &entry); err != nil || entry.Work == nil {
+			return nil, fmt.Errorf("%s %s, offset %d is not a worklog", e.RepoGetFilesRead, r.path, line.Offset)
+		}
+		entry.Work.Sanitize()
+		found = append(found, entry.Work)
+	}
+	return found, nil
+}
+
+// apply adds the entry, at the line of the log, to the index.
+// Later lines replace earlier lines saving the same revision.
+func (index *jsonlIndex) apply(entry jsonlEntry, line jsonlLine) {
+	if entry.Work == nil {
+		delete(index.Worklogs, entry.Delete)
+		return
+	}
+
+	wl := entry.Work
+	current, ok := index.Worklogs[wl.ID]
+	if !ok {
+		current = &jsonlIndexEntry{Revisions: make(map[int]jsonlLine)}
+		index.Worklogs[wl.ID] = current
+	}
+	current.Revisions[wl.Revision] = line
+	// Older revisions are only kept in the history
+	if !ok || current.Latest <= wl.Revision {
+		current.Latest = wl.Revision
+		current.WhenQueryEpoch = wl.When.Unix()
+	}
+}
+
+// matchID the ID of the work matching, the same as the bolt repository
+func (index *jsonlIndex) matchID(ID string) (string, error) {
+	re, err := regexp.Compile(helpers.RegexCaseInsensitive + ID)
+	if err != nil {
+		return "", err
+	}
+	found := ""
+	for id := range index.Worklogs {
+		if !re.MatchString(id) {
+			continue
+		} else if found != "" {
+			return "", errors.New(e.RepoGetSingleFileAmbiguous)
+		}
+		found = id
+	}
+	return found, nil
+}
+
+// revisionLines the lines of every revision of the work, in order
+func (index *jsonlIndex) revisionLines(id string) []jsonlLine {
+	entry := index.Worklogs[id]
+	revisions := make([]int, 0, len(entry.Revisions))
+	for revision := range entry.Revisions {
+		revisions = append(revisions, revision)
+	}
+	sort.Ints(revisions)
+
+	lines := make([]jsonlLine, len(revisions))
+	for i, revision := range revisions {
+		lines[i] = entry.Revisions[revision]
+	}
+	return lines
+}
+
+// writeFileAtomically writes alongside the file then renames it,
+// so a failed write keeps the file as it was
+func writeFileAtomically(path string, b []byte) error {
+	if err := createDirectory(filepath.Dir(path)); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONLRepositoryLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worklog.jsonl")
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+
+	r := NewJSONLRepo(path)
+	assert.Nil(t, r.Init())
+	first := model.NewWork("First", "", "Alice", 30, []string{"log"}, when)
+	second := model.NewWork("Second", "", "Alice", 30, []string{"log"}, when.Add(time.Hour))
+	for _, wl := range []*model.Work{first, second} {
+		assert.Nil(t, r.Save(wl))
+	}
+	edit(t, r, first.ID, model.Work{Title: "First edited"})
+	assert.Nil(t, r.Delete(second.ID))
+
+	b, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(b), "\n"), "\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, fmt.Sprintf(`{"delete":"%s"}`, second.ID), lines[3])
+	assert.FileExists(t, path+jsonlIndexSuffix)
+
+	// Appended by another branch, and merged without a final new line
+	merged := model.NewWork("Merged", "", "Bob", 15, []string{"log"}, when.Add(2*time.Hour))
+	line, err := json.Marshal(jsonlEntry{Work: merged})
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(path, append(b, line...), 0600))
+
+	found := between(t, r, time.Time{}, &model.Work{Tags: []string{"log"}})
+	assert.Len(t, found, 2)
+	assert.Equal(t, "First edited", found[0].Title)
+	assert.Equal(t, "Merged", found[1].Title)
+
+	edit(t, r, merged.ID, model.Work{Title: "Merged edited"})
+	revisions, err := r.GetRevisions(merged.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "Merged edited", revisions[1].Title)
+
+	b, err = os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Nil(t, os.WriteFile(path, append(b, []byte("<<<<<<< HEAD\n")...), 0600))
+	_, err = r.GetAllBetweenDates(time.Time{}, farFuture, &model.Work{})
+	assert.ErrorContains(t, err, "line 7 is not a worklog")
+}
+
+func TestJSONLRepositoryIndexRebuilt(t *testing.T) {
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	replacement := helpers.RandHexAlphaNumericString(20)
+
+	var tests = []struct {
+		name   string
+		change func(t *testing.T, r *jsonlRepo, saved *model.Work)
+		expIDs func(saved *model.Work) []string
+	}{
+		{
+			name: "Unchanged log keeps its index",
+			change: func(t *testing.T, r *jsonlRepo, _ *model.Work) {
+				// Only noticed if the index is used as it is
+				index, err := r.readIndex()
+				assert.Nil(t, err)
+				index.Worklogs = map[string]*jsonlIndexEntry{}
+				assert.Nil(t, r.writeIndex(index))
+			},
+			expIDs: func(*model.Work) []string {
+				return []string{}
+			},
+		}, {
+			name: "Log changed size",
+			change: func(t *testing.T, r *jsonlRepo, _ *model.Work) {
+				appended := model.NewWork("Appended", "", "Bob", 15, nil, when)
+				appended.ID = replacement
+				line, err := json.Marshal(jsonlEntry{Work: appended})
+				assert.Nil(t, err)
+				file, err := os.OpenFile(r.path, os.O_APPEND|os.O_WRONLY, 0600)
+				assert.Nil(t, err)
+				_, err = file.Write(append(line, '\n'))
+				assert.Nil(t, err)
+				assert.Nil(t, file.Close())
+			},
+			expIDs: func(saved *model.Work) []string {
+				return []string{saved.ID, replacement}
+			},
+		}, {
+			name: "Log changed at the same size",
+			change: func(t *testing.T, r *jsonlRepo, saved *model.Work) {
+				b, err := os.ReadFile(r.path)
+				assert.Nil(t, err)
+				changed := bytes.ReplaceAll(b, []byte(saved.ID), []byte(replacement))
+				assert.Len(t, changed, len(b))
+				assert.Nil(t, os.WriteFile(r.path, changed, 0600))
+				later := time.Now().Add(time.Hour)
+				assert.Nil(t, os.Chtimes(r.path, later, later))
+			},
+			expIDs: func(*model.Work) []string {
+				return []string{replacement}
+			},
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			r := NewJSONLRepo(filepath.Join(t.TempDir(), "worklog.jsonl")).(*jsonlRepo)
+			saved := model.NewWork("Saved", "", "Alice", 30, nil, when)
+			assert.Nil(t, r.Save(saved))
+
+			testItem.change(t, r, saved)
+
+			index, err := r.readIndex()
+			assert.Nil(t, err)
+			ids := []string{}
+			for id := range index.Worklogs {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			expIDs := testItem.expIDs(saved)
+			sort.Strings(expIDs)
+			assert.Equal(t, expIDs, ids)
+
+			info, err := os.Stat(r.path)
+			assert.Nil(t, err)
+			assert.Equal(t, info.Size(), index.Size)
+			assert.Equal(t, info.ModTime().UnixNano(), index.ModTime)
+		})
+	}
+}
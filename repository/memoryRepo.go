@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+type memoryRepo struct {
+	path string
+
+	lock    sync.RWMutex
+	load    sync.Once
+	loadErr error
+	// revisions of work by ID, ordered by revision
+	revisions map[string][]*model.Work
+	timer     *model.Work
+}
+
+// memorySnapshot is how the memory repository is persisted
+type memorySnapshot struct {
+	Worklogs []*model.Work `json:"worklogs"`
+	Timer    *model.Work   `json:"timer,omitempty"`
+}
+
+// NewMemoryRepo initializes the repo, which only holds worklogs
+// while running. If a path is given, worklogs are loaded from a JSON
+// snapshot at the path, and written back to it when snapshotted.
+func NewMemoryRepo(path string) WorklogRepository {
+	return &memoryRepo{
+		path:      path,
+		revisions: make(map[string][]*model.Work),
+	}
+}
+
+func (r *memoryRepo) Init() error {
+	return r.loadSnapshot()
+}
+
+// Close doesn't snapshot the worklogs, which is left to the caller
+func (*memoryRepo) Close() error {
+	return nil
+}
+
+func (r *memoryRepo) Save(wl *model.Work) error {
+	if err := r.loadSnapshot(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	helpers.LogDebug("Saving file...", "save model - memory")
+	r.save(wl)
+	helpers.LogDebug("Saved file", "save model successful - memory")
+	return nil
+}
+
+func (r *memoryRepo) Delete(id string) error {
+	if err := r.loadSnapshot(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	helpers.LogDebug("Deleting worklog...", "delete model - memory")
+	if _, ok := r.revisions[id]; !ok {
+		return errors.New(e.RepoDeleteNotFound)
+	}
+	delete(r.revisions, id)
+
+	helpers.LogDebug("Deleted worklog", "delete model successful - memory")
+	return nil
+}
+
+func (r *memoryRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
+	if err := r.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	found := []*model.Work{}
+	for _, revs := range r.revisions {
+		wl := revs[len(revs)-1]
+		if wl.WhenQueryEpoch >= startDate.Unix() &&
+			wl.WhenQueryEpoch < endDate.Unix() &&
+			matches(wl) {
+			found = append(found, copyWork(wl))
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].WhenQueryEpoch != found[j].WhenQueryEpoch {
+			return found[i].WhenQueryEpoch < found[j].WhenQueryEpoch
+		}
+		return found[i].ID < found[j].ID
+	})
+	return found, nil
+}
+
+func (r *memoryRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
+	if err := r.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	found, err := r.latestByID(ID)
+	if err != nil || found == nil || !matches(found) {
+		return nil, err
+	}
+	return copyWork(found), nil
+}
+
+func (r *memoryRepo) GetRevisions(ID string) ([]*model.Work, error) {
+	if err := r.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	found, err := r.latestByID(ID)
+	if err != nil {
+		return nil, err
+	} else if found == nil {
+		return []*model.Work{}, nil
+	}
+	revs := []*model.Work{}
+	for _, wl := range r.revisions[found.ID] {
+		revs = append(revs, copyWork(wl))
+	}
+	return revs, nil
+}
+
+func (r *memoryRepo) GetAll() ([]*model.Work, error) {
+	if err := r.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.all(), nil
+}
+
+func (r *memoryRepo) SaveTimer(wl *model.Work) error {
+	if err := r.loadSnapshot(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.timer = copyWork(wl)
+	return nil
+}
+
+func (r *memoryRepo) GetTimer() (*model.Work, error) {
+	if err := r.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.timer == nil {
+		return nil, nil
+	}
+	return copyWork(r.timer), nil
+}
+
+func (r *memoryRepo) DeleteTimer() error {
+	if err := r.loadSnapshot(); err != nil {
+		return err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.timer = nil
+	return nil
+}
+
+// Snapshot writes every revision of every worklog, and any running
+// timer, to the path as JSON. Without a path, nothing is written.
+func (r *memoryRepo) Snapshot() error {
+	if r.path == "" {
+		return nil
+	}
+	if err := r.loadSnapshot(); err != nil {
+		return err
+	}
+	r.lock.RLock()
+	snapshot := memorySnapshot{Worklogs: r.all(), Timer: r.timer}
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	r.lock.RUnlock()
+	if err != nil {
+		return err
+	}
+
+	helpers.LogDebug(fmt.Sprintf("Saving snapshot of %d revisions...", len(snapshot.Worklogs)), "snapshot - memory")
+	if err := writeFileAtomically(r.path, b); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving snapshot: %s", err.Error()), "snapshot error - memory")
+		return err
+	}
+	helpers.LogDebug("Saved snapshot", "snapshot successful - memory")
+	return nil
+}
+
+// save the revision of work, replacing it if already saved,
+// requiring the lock to be held
+func (r *memoryRepo) save(wl *model.Work) {
+	saved := copyWork(wl)
+	saved.WhenQueryEpoch = saved.When.Unix()
+	revs := r.revisions[wl.ID]
+	i := sort.Search(len(revs), func(i int) bool {
+		return revs[i].Revision >= wl.Revision
+	})
+	if i < len(revs) && revs[i].Revision == wl.Revision {
+		revs[i] = saved
+	} else {
+		revs = append(revs, nil)
+		copy(revs[i+1:], revs[i:])
+		revs[i] = saved
+	}
+	r.revisions[wl.ID] = revs
+}
+
+// loadSnapshot reads the snapshot at the path the first time it is
+// called. No snapshot existing isn't an error, as it is written later.
+func (r *memoryRepo) loadSnapshot() error {
+	r.load.Do(func() {
+		if r.path == "" {
+			return
+		}
+		b, err := os.ReadFile(r.path)
+		if errors.Is(err, os.ErrNotExist) {
+			return
+		} else if err != nil {
+			r.loadErr = fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+			return
+		}
+		var snapshot memorySnapshot
+		if err := json.Unmarshal(b, &snapshot); err != nil {
+			r.loadErr = fmt.Errorf("%s. %s", e.RepoGetFilesRead, err.Error())
+			return
+		}
+
+		helpers.LogDebug(fmt.Sprintf("loading snapshot of %d revisions", len(snapshot.Worklogs)), "load snapshot - memory")
+		r.lock.Lock()
+		defer r.lock.Unlock()
+		for _, wl := range snapshot.Worklogs {
+			wl.Sanitize()
+			r.save(wl)
+		}
+		if snapshot.Timer != nil {
+			snapshot.Timer.Sanitize()
+			r.timer = snapshot.Timer
+		}
+	})
+	return r.loadErr
+}
+
+// latestByID the latest revision of the work with an ID matching,
+// requiring the lock to be held
+func (r *memoryRepo) latestByID(ID string) (*model.Work, error) {
+	re, err := regexp.Compile(helpers.RegexCaseInsensitive + ID)
+	if err != nil {
+		return nil, err
+	}
+	var found *model.Work
+	for id, revs := range r.revisions {
+		if !re.MatchString(id) {
+			continue
+		} else if found != nil {
+			return nil, errors.New(e.RepoGetSingleFileAmbiguous)
+		}
+		found = revs[len(revs)-1]
+	}
+	return found, nil
+}
+
+// all every revision of work sorted by ID then revision,
+// requiring the lock to be held
+func (r *memoryRepo) all() []*model.Work {
+	all := []*model.Work{}
+	for _, revs := range r.revisions {
+		for _, wl := range revs {
+			all = append(all, copyWork(wl))
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].ID != all[j].ID {
+			return all[i].ID < all[j].ID
+		}
+		return all[i].Revision < all[j].Revision
+	})
+	return all
+}
+
+// copyWork so work held by the repository can't be changed
+// outside of it
+func copyWork(wl *model.Work) *model.Work {
+	copied := *wl
+	if wl.Tags != nil {
+		copied.Tags = append([]string{}, wl.Tags...)
+	}
+	return &copied
+}
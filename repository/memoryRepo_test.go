@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRepositorySnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot", "worklogs.json")
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+
+	r := NewMemoryRepo(path)
+	wl := model.NewWork("Demo", "", "Alice", 30, []string{"demo"}, when)
+	assert.Nil(t, r.Save(wl))
+	edit(t, r, wl.ID, model.Work{Title: "Demo edited"})
+	assert.Nil(t, r.(TimerRepository).SaveTimer(&model.Work{Title: "Timed", When: when}))
+	assert.NoFileExists(t, path)
+	assert.Nil(t, r.(SnapshotRepository).Snapshot())
+
+	r = NewMemoryRepo(path)
+	revisions, err := r.GetRevisions(wl.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "Demo edited", revisions[1].Title)
+	assert.Equal(t, []string{"demo"}, revisions[1].Tags)
+	timer, err := r.(TimerRepository).GetTimer()
+	assert.Nil(t, err)
+	assert.Equal(t, "Timed", timer.Title)
+
+	assert.Nil(t, os.WriteFile(path, []byte("{"), 0600))
+	r = NewMemoryRepo(path)
+	_, err = r.GetAllBetweenDates(time.Time{}, farFuture, &model.Work{})
+	assert.ErrorContains(t, err, e.RepoGetFilesRead)
+}
+
+func TestMemoryRepositoryConcurrently(t *testing.T) {
+	r := NewMemoryRepo("")
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			wl := model.NewWork(fmt.Sprintf("Work %d", i), "", "Alice", 15, []string{"load"}, when)
+			assert.Nil(t, r.Save(wl))
+			edit(t, r, wl.ID, model.Work{Description: "edited"})
+			_, err := r.GetAllBetweenDates(time.Time{}, farFuture, &model.Work{Tags: []string{"load"}})
+			assert.Nil(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	found := between(t, r, time.Time{}, &model.Work{Description: "edited"})
+	assert.Len(t, found, 50)
+	all, err := r.GetAll()
+	assert.Nil(t, err)
+	assert.Len(t, all, 100)
+}
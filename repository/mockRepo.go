@@ -23,12 +23,24 @@ func (m *MockRepo) Init() error {
 	return args.Error(0)
 }
 
+// Close WorklogRepository method for testing
+func (m *MockRepo) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
 // Save WorklogRepository method for testing
 func (m *MockRepo) Save(wl *model.Work) error {
 	args := m.Called(wl)
 	return args.Error(0)
 }
 
+// Delete WorklogRepository method for testing
+func (m *MockRepo) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
 // GetAllBetweenDates WorklogRepository method for testing
 func (m *MockRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
 	args := m.Called(startDate, endDate, filter)
@@ -41,8 +53,32 @@ func (m *MockRepo) GetByID(id string, filter *model.Work) (*model.Work, error) {
 	return args.Get(0).(*model.Work), args.Error(1)
 }
 
+// GetRevisions WorklogRepository method for testing
+func (m *MockRepo) GetRevisions(id string) ([]*model.Work, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*model.Work), args.Error(1)
+}
+
 // GetAll WorklogRepository method for testing
 func (m *MockRepo) GetAll() ([]*model.Work, error) {
 	args := m.Called()
 	return args.Get(0).([]*model.Work), args.Error(1)
 }
+
+// SaveTimer TimerRepository method for testing
+func (m *MockRepo) SaveTimer(wl *model.Work) error {
+	args := m.Called(wl)
+	return args.Error(0)
+}
+
+// GetTimer TimerRepository method for testing
+func (m *MockRepo) GetTimer() (*model.Work, error) {
+	args := m.Called()
+	return args.Get(0).(*model.Work), args.Error(1)
+}
+
+// DeleteTimer TimerRepository method for testing
+func (m *MockRepo) DeleteTimer() error {
+	args := m.Called()
+	return args.Error(0)
+}
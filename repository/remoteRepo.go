@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+const (
+	remotePath     = "/worklog"
+	remotePullPath = remotePath + "/sync/pull"
+	remotePushPath = remotePath + "/sync/push"
+	remoteTimeout  = time.Second * 10
+)
+
+type remoteRepo struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+// NewRemoteRepo initializes the repo, which stores worklogs
+// in the worklog-server at the url, authorising with the token
+// if one is given
+func NewRemoteRepo(url, token string) WorklogRepository {
+	return &remoteRepo{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: &http.Client{Timeout: remoteTimeout},
+	}
+}
+
+func (*remoteRepo) Init() error {
+	return nil
+}
+
+// Close any connections to the server kept for reuse
+func (r *remoteRepo) Close() error {
+	r.client.CloseIdleConnections()
+	return nil
+}
+
+// Save the revision of work exactly as it is, keeping its ID and source,
+// so work imported again is recognised. Saving a revision the server
+// already has with different content is refused as a conflict.
+func (r *remoteRepo) Save(wl *model.Work) error {
+	helpers.LogDebug("Saving worklog...", "save model - remote")
+	if err := r.SaveRevisions([]*model.Work{wl}); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	helpers.LogDebug("Saved worklog", "save model successful - remote")
+	return nil
+}
+
+func (r *remoteRepo) Delete(id string) error {
+	helpers.LogDebug("Deleting worklog...", "delete model - remote")
+	status, err := r.do(http.MethodDelete, idPath(id), url.Values{"purge": {"true"}}, nil, nil)
+	if err != nil {
+		return err
+	} else if status == http.StatusNotFound {
+		return errors.New(e.RepoDeleteNotFound)
+	}
+	helpers.LogDebug("Deleted worklog", "delete model successful - remote")
+	return nil
+}
+
+func (r *remoteRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
+	query := url.Values{
+		"startDate": {helpers.TimeFormat(startDate)},
+		"endDate":   {helpers.TimeFormat(endDate)},
+	}
+	if filter != nil {
+		query.Set("title", filter.Title)
+		query.Set("description", filter.Description)
+		query.Set("author", filter.Author)
+		query.Set("tags", strings.Join(filter.Tags, ","))
+	}
+
+	found := []*model.Work{}
+	if _, err := r.do(http.MethodGet, remotePath, query, nil, &found); err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (r *remoteRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	revs, err := r.GetRevisions(ID)
+	if err != nil || len(revs) == 0 {
+		return nil, err
+	}
+	// Revisions include deleted work, unlike getting the work itself
+	latest := revs[len(revs)-1]
+	if !matches(latest) {
+		return nil, nil
+	}
+	return latest, nil
+}
+
+func (r *remoteRepo) GetRevisions(ID string) ([]*model.Work, error) {
+	revs := []*model.Work{}
+	if _, err := r.do(http.MethodGet, idPath(ID)+"/revisions", nil, nil, &revs); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(revs, func(i, j int) bool {
+		return revs[i].Revision < revs[j].Revision
+	})
+	return revs, nil
+}
+
+// GetAll every revision of the work the server has, other than work
+// which has been deleted
+func (r *remoteRepo) GetAll() ([]*model.Work, error) {
+	latest := []*model.Work{}
+	if _, err := r.do(http.MethodGet, remotePath, nil, nil, &latest); err != nil {
+		return nil, err
+	}
+
+	all := []*model.Work{}
+	for _, wl := range latest {
+		revs, err := r.GetRevisions(wl.ID)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, revs...)
+	}
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].ID != all[j].ID {
+			return all[i].ID < all[j].ID
+		}
+		return all[i].Revision < all[j].Revision
+	})
+	return all, nil
+}
+
+// RevisionsAfter every revision the server has of each worklog
+// after the revision marked for its ID
+func (r *remoteRepo) RevisionsAfter(marks map[string]int) ([]*model.Work, error) {
+	revs := []*model.Work{}
+	if _, err := r.do(http.MethodPost, remotePullPath, nil, marks, &revs); err != nil {
+		return nil, err
+	}
+	return revs, nil
+}
+
+// SaveRevisions exactly as they are in the server, which refuses
+// all of them if any would replace a different revision
+func (r *remoteRepo) SaveRevisions(wls []*model.Work) error {
+	conflicts := []string{}
+	status, err := r.do(http.MethodPost, remotePushPath, nil, wls, &conflicts)
+	if status == http.StatusConflict {
+		return fmt.Errorf("%s: %s", e.RepoSyncConflict, strings.Join(conflicts, ", "))
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// do sends the request to the server, decoding the response into out.
+// Not found isn't an error, as the server responds with it when there
+// is no work, so is left to the caller. Conflicts are also decoded.
+func (r *remoteRepo) do(method, path string, query url.Values, in, out interface{}) (int, error) {
+	if r.url == "" {
+		return 0, errors.New(e.RepoRemoteURL)
+	}
+	var body bytes.Buffer
+	if in != nil {
+		if err := json.NewEncoder(&body).Encode(in); err != nil {
+			return 0, err
+		}
+	}
+	endpoint := r.url + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, endpoint, &body)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s. %s", e.RepoRemoteUnreachable, r.url, err.Error())
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("%s %s. %s", e.RepoRemoteUnreachable, r.url, err.Error())
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			helpers.LogError(fmt.Sprintf("Error closing response: %s", err.Error()), "request error - remote")
+		}
+	}()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return resp.StatusCode, nil
+	} else if resp.StatusCode == http.StatusConflict && out != nil {
+		// Conflicts describe what conflicted
+		_ = json.NewDecoder(resp.Body).Decode(out)
+		return resp.StatusCode, remoteStatusError(resp.StatusCode)
+	} else if resp.StatusCode >= http.StatusBadRequest {
+		return resp.StatusCode, remoteStatusError(resp.StatusCode)
+	}
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("%s. %s", e.RepoRemoteResponse, err.Error())
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// remoteStatusError describes why the server didn't
+// respond successfully
+func remoteStatusError(status int) error {
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return errors.New(e.RepoRemoteUnauthorized)
+	}
+	return fmt.Errorf("%s. %d %s", e.RepoRemoteResponse, status, http.StatusText(status))
+}
+
+// idPath the path of the work with the ID on the server
+func idPath(ID string) string {
+	return remotePath + "/" + url.PathEscape(ID)
+}
@@ -11,10 +11,55 @@ import (
 type WorklogRepository interface {
 	Init() error
 	Save(wl *model.Work) error
+	Delete(id string) error
 
 	GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error)
 	GetByID(id string, filter *model.Work) (*model.Work, error)
+	GetRevisions(id string) ([]*model.Work, error)
 	GetAll() ([]*model.Work, error)
+
+	Close() error
+}
+
+// TimerRepository defines what a store for
+// a running timer should be capable of doing
+type TimerRepository interface {
+	SaveTimer(wl *model.Work) error
+	GetTimer() (*model.Work, error)
+	DeleteTimer() error
+}
+
+// SnapshotRepository defines a repository which holds worklogs
+// in memory, and must write them out to keep them
+type SnapshotRepository interface {
+	Snapshot() error
+}
+
+// SyncRepository defines a repository which can
+// share worklogs with a remote copy of itself
+type SyncRepository interface {
+	Sync(remoteURL string) (*model.SyncSummary, error)
+}
+
+// ReplicaRepository defines a repository which revisions of
+// worklogs can be copied to and from exactly as they are
+type ReplicaRepository interface {
+	RevisionsAfter(marks map[string]int) ([]*model.Work, error)
+	SaveRevisions(wls []*model.Work) error
+}
+
+// SyncMarkRepository defines a repository which keeps how far
+// it has synced each worklog with each remote
+type SyncMarkRepository interface {
+	GetSyncMarks(remote string) (map[string]int, error)
+	SaveSyncMarks(remote string, marks map[string]int) error
+}
+
+// LegacyDurationRepository defines a repository which converts
+// durations recorded before they were stored as minutes, when
+// it is initialised
+type LegacyDurationRepository interface {
+	SetLegacyDurationMinutes(minutes int)
 }
 
 // ConfigRepository defines what a configuration
@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var farFuture = time.Date(3000, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// edit saves the next revision of the work, updated as the service would
+func edit(t *testing.T, r WorklogRepository, id string, newWl model.Work) *model.Work {
+	t.Helper()
+	wl, err := r.GetByID(id, &model.Work{})
+	assert.Nil(t, err)
+	wl.Update(newWl)
+	assert.Nil(t, r.Save(wl))
+	return wl
+}
+
+// between the latest revision of work from the start, sorted by when
+func between(t *testing.T, r WorklogRepository, start time.Time, filter *model.Work) []*model.Work {
+	t.Helper()
+	found, err := r.GetAllBetweenDates(start, farFuture, filter)
+	assert.Nil(t, err)
+	sort.Sort(model.WorkList(found))
+	return found
+}
+
+func TestWorklogsWithRepositories(t *testing.T) {
+	var tests = []struct {
+		name string
+		repo func(dir string) WorklogRepository
+	}{
+		{
+			name: "Bolt",
+			repo: func(dir string) WorklogRepository {
+				return NewBBoltRepo(filepath.Join(dir, "worklog.db"))
+			},
+		}, {
+			name: "Persistent bolt",
+			repo: func(dir string) WorklogRepository {
+				return NewPersistentBBoltRepo(filepath.Join(dir, "worklog.db"))
+			},
+		}, {
+			name: "Sqlite",
+			repo: func(dir string) WorklogRepository {
+				return NewSQLiteRepo(filepath.Join(dir, "worklog.sqlite"))
+			},
+		}, {
+			name: "Jsonl",
+			repo: func(dir string) WorklogRepository {
+				return NewJSONLRepo(filepath.Join(dir, "worklog.jsonl"))
+			},
+		}, {
+			name: "Git",
+			repo: func(dir string) WorklogRepository {
+				return NewGitRepo(filepath.Join(dir, "git"))
+			},
+		}, {
+			name: "Memory",
+			repo: func(string) WorklogRepository {
+				return NewMemoryRepo("")
+			},
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			r := testItem.repo(t.TempDir())
+			defer func() {
+				assert.Nil(t, r.Close())
+			}()
+			testWorklogsWithRepository(t, r)
+		})
+	}
+}
+
+func testWorklogsWithRepository(t *testing.T, r WorklogRepository) {
+	assert.Nil(t, r.Init())
+
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	worklogs := []*model.Work{
+		model.NewWork("Review 100% done", "", "Alice", 30, []string{"review", "client_a"}, when),
+		model.NewWork("Planning", "Sprint", "Bob", 60, []string{"meeting"}, when.Add(time.Hour)),
+		model.NewWork("Pairing", "", "Alice", 45, []string{"client_b"}, when.Add(-48*time.Hour)),
+	}
+	for _, wl := range worklogs {
+		assert.Nil(t, r.Save(wl))
+	}
+
+	found, err := r.GetAllBetweenDates(when.Add(-time.Hour), when.Add(24*time.Hour), &model.Work{})
+	assert.Nil(t, err)
+	assert.Len(t, found, 2)
+
+	found = between(t, r, time.Time{}, &model.Work{Title: "100%", Tags: []string{"CLIENT_*"}})
+	assert.Len(t, found, 1)
+	assert.Equal(t, worklogs[0].ID, found[0].ID)
+	assert.Equal(t, []string{"client_a", "review"}, found[0].Tags)
+
+	found = between(t, r, time.Time{}, &model.Work{Tags: []string{"client*"}})
+	assert.Len(t, found, 2)
+	assert.Equal(t, worklogs[2].ID, found[0].ID)
+
+	found, err = r.GetAllBetweenDates(when.Add(-time.Hour), when.Add(24*time.Hour), &model.Work{Tags: []string{"client*", "Review"}})
+	assert.Nil(t, err)
+	assert.Len(t, found, 1)
+	assert.Equal(t, worklogs[0].ID, found[0].ID)
+
+	found = between(t, r, time.Time{}, &model.Work{Title: "^plan|^PAIR", Author: "b|e$"})
+	assert.Len(t, found, 2, "title, description and author are expressions")
+	assert.Equal(t, worklogs[2].ID, found[0].ID)
+
+	for _, tags := range [][]string{{"client"}, {"client%"}, {"view"}, {"review", "meeting"}} {
+		found = between(t, r, time.Time{}, &model.Work{Tags: tags})
+		assert.Len(t, found, 0, "tags %v only match whole tags or prefixes", tags)
+	}
+
+	byID, err := r.GetByID(worklogs[0].ID, nil)
+	assert.Nil(t, err, "a nil filter matches everything")
+	assert.Equal(t, worklogs[0].ID, byID.ID)
+
+	edit(t, r, worklogs[1].ID, model.Work{Title: "Planning poker"})
+	revisions, err := r.GetRevisions(worklogs[1].ID[:8])
+	assert.Nil(t, err)
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, "Planning", revisions[0].Title)
+	assert.Equal(t, "Planning poker", revisions[1].Title)
+	assert.Equal(t, []string{"meeting"}, revisions[1].Tags)
+
+	edit(t, r, worklogs[1].ID, model.Work{Tags: []string{"retro"}})
+	found = between(t, r, time.Time{}, &model.Work{Tags: []string{"meeting"}})
+	assert.Len(t, found, 0, "previous tags are no longer found")
+	found = between(t, r, time.Time{}, &model.Work{Tags: []string{"retro"}})
+	assert.Len(t, found, 1)
+	assert.Equal(t, "Planning poker", found[0].Title)
+
+	assert.Nil(t, r.Delete(worklogs[2].ID))
+	deleted, err := r.GetByID(worklogs[2].ID, &model.Work{})
+	assert.Nil(t, err)
+	assert.Nil(t, deleted)
+	found = between(t, r, time.Time{}, &model.Work{Tags: []string{"client_b"}})
+	assert.Len(t, found, 0)
+
+	timers, ok := r.(TimerRepository)
+	assert.True(t, ok)
+	timer, err := timers.GetTimer()
+	assert.Nil(t, err)
+	assert.Nil(t, timer)
+	assert.Nil(t, timers.SaveTimer(&model.Work{Title: "Timed", When: when}))
+	timer, err = timers.GetTimer()
+	assert.Nil(t, err)
+	assert.Equal(t, "Timed", timer.Title)
+	assert.Nil(t, timers.DeleteTimer())
+	timer, err = timers.GetTimer()
+	assert.Nil(t, err)
+	assert.Nil(t, timer)
+}
+
+func TestGetTimerBeforeSaving(t *testing.T) {
+	dir := t.TempDir()
+	for name, r := range map[string]WorklogRepository{
+		"Bolt":   NewBBoltRepo(filepath.Join(dir, "worklog.db")),
+		"Sqlite": NewSQLiteRepo(filepath.Join(dir, "worklog.sqlite")),
+		"Jsonl":  NewJSONLRepo(filepath.Join(dir, "worklog.jsonl")),
+		"Legacy": NewYamlFileRepo(filepath.Join(dir, "legacy")),
+	} {
+		timer, err := r.(TimerRepository).GetTimer()
+		assert.Nil(t, err, name)
+		assert.Nil(t, timer, name)
+	}
+}
+
+func TestSaveOutsideRepository(t *testing.T) {
+	dir := t.TempDir()
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	repos := map[string]WorklogRepository{
+		"Legacy": NewYamlFileRepo(filepath.Join(dir, "legacy")),
+		"Git":    NewGitRepo(filepath.Join(dir, "git")),
+	}
+	for name, r := range repos {
+		assert.Nil(t, r.Init(), name)
+		for _, id := range []string{"../../outside", "..", "sub/dir"} {
+			wl := model.NewWork("Outside", "", "Alice", 30, nil, when)
+			wl.ID = id
+			assert.ErrorContains(t, r.Save(wl), e.RepoFileName, name)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, len(repos), "nothing is written outside of the repository")
+}
+
+func TestLegacyGetByAmbiguousID(t *testing.T) {
+	r := NewYamlFileRepo(t.TempDir())
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	for _, id := range []string{"abc1", "abc2"} {
+		wl := model.NewWork("Ambiguous", "", "Alice", 30, nil, when)
+		wl.ID = id
+		assert.Nil(t, r.Save(wl))
+	}
+
+	_, err := r.GetByID("abc", &model.Work{})
+	assert.EqualError(t, err, "ID 'abc' is not unique")
+}
@@ -0,0 +1,534 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	// Pure Go driver, so building doesn't require cgo
+	_ "modernc.org/sqlite"
+)
+
+// Every revision of work is stored, with the latest revision of each
+// worklog flagged so it can be queried without scanning its history
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS worklogs (
+	id          TEXT    NOT NULL,
+	revision    INTEGER NOT NULL,
+	latest      INTEGER NOT NULL DEFAULT 0,
+	title       TEXT    NOT NULL,
+	description TEXT    NOT NULL DEFAULT '',
+	author      TEXT    NOT NULL DEFAULT '',
+	duration    INTEGER NOT NULL DEFAULT 0,
+	when_at     TEXT    NOT NULL,
+	when_epoch  INTEGER NOT NULL,
+	created_at  TEXT    NOT NULL,
+	deleted     INTEGER NOT NULL DEFAULT 0,
+	source      TEXT    NOT NULL DEFAULT '',
+	PRIMARY KEY (id, revision)
+);
+CREATE INDEX IF NOT EXISTS worklogs_latest_when ON worklogs (latest, when_epoch);
+
+CREATE TABLE IF NOT EXISTS tags (
+	id       TEXT    NOT NULL,
+	revision INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	tag      TEXT    NOT NULL,
+	tag_key  TEXT    NOT NULL DEFAULT '',
+	PRIMARY KEY (id, revision, position),
+	FOREIGN KEY (id, revision) REFERENCES worklogs (id, revision) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS timer (
+	key  TEXT PRIMARY KEY,
+	work TEXT NOT NULL
+);`
+
+// sqliteSelect every column of work, with its tags as a JSON array in order
+const sqliteSelect = `SELECT w.id, w.revision, w.title, w.description, w.author, w.duration,
+	w.when_at, w.when_epoch, w.created_at, w.deleted, w.source,
+	(SELECT json_group_array(tag) FROM (
+		SELECT t.tag FROM tags t WHERE t.id = w.id AND t.revision = w.revision ORDER BY t.position))
+	FROM worklogs w`
+
+// sqliteSchemaVersion the user version of databases with the current
+// schema. Version 1 indexes tags lowercased, as tag_key, so filtering
+// by them can use the index while ignoring case.
+const sqliteSchemaVersion = 1
+
+var sqliteLikeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+type sqliteRepo struct {
+	path string
+}
+
+// NewSQLiteRepo initializes the repo with the given filepath
+func NewSQLiteRepo(path string) WorklogRepository {
+	return &sqliteRepo{path: path}
+}
+
+func (r *sqliteRepo) Init() error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return openErr
+	}
+	return db.Close()
+}
+
+// Close has nothing to close, as the database is opened for each operation
+func (*sqliteRepo) Close() error {
+	return nil
+}
+
+func (r *sqliteRepo) Save(wl *model.Work) error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	helpers.LogDebug("Saving file...", "save model - sqlite")
+	// Older revisions are only kept in the history
+	var current int
+	currentErr := tx.QueryRow(`SELECT revision FROM worklogs WHERE id = ? AND latest = 1`, wl.ID).Scan(&current)
+	if currentErr != nil && currentErr != sql.ErrNoRows {
+		return currentErr
+	}
+	latest := currentErr == sql.ErrNoRows || current <= wl.Revision
+	if latest {
+		if _, err := tx.Exec(`UPDATE worklogs SET latest = 0 WHERE id = ?`, wl.ID); err != nil {
+			return err
+		}
+	}
+
+	// Replacing a revision also removes its tags
+	if _, err := tx.Exec(`DELETE FROM worklogs WHERE id = ? AND revision = ?`, wl.ID, wl.Revision); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`INSERT INTO worklogs
+		(id, revision, latest, title, description, author, duration, when_at, when_epoch, created_at, deleted, source)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		wl.ID, wl.Revision, latest, wl.Title, wl.Description, wl.Author, wl.Duration,
+		wl.When.Format(time.RFC3339Nano), wl.When.Unix(), wl.CreatedAt.Format(time.RFC3339Nano),
+		wl.Deleted, wl.Source); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving revision: %s", err.Error()), "save model error - sqlite")
+		return err
+	}
+	for position, tag := range wl.Tags {
+		if _, err := tx.Exec(`INSERT INTO tags (id, revision, position, tag, tag_key) VALUES (?, ?, ?, ?, ?)`,
+			wl.ID, wl.Revision, position, tag, strings.ToLower(tag)); err != nil {
+			helpers.LogError(fmt.Sprintf("Error saving tags: %s", err.Error()), "save model error - sqlite")
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	helpers.LogDebug("Saved file", "save model successful - sqlite")
+	return nil
+}
+
+func (r *sqliteRepo) Delete(id string) error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	helpers.LogDebug("Deleting worklog...", "delete model - sqlite")
+	result, err := db.Exec(`DELETE FROM worklogs WHERE id = ?`, id)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("Error deleting worklog: %s", err.Error()), "delete model error - sqlite")
+		return err
+	}
+	if deleted, err := result.RowsAffected(); err != nil {
+		return err
+	} else if deleted == 0 {
+		return errors.New(e.RepoDeleteNotFound)
+	}
+
+	helpers.LogDebug("Deleted worklog", "delete model successful - sqlite")
+	return nil
+}
+
+func (r *sqliteRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
+	db, openErr := r.openReadOnly()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return nil, openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	where, args := sqliteTagFilter(filter)
+	args = append([]any{startDate.Unix(), endDate.Unix()}, args...)
+	found, err := queryWork(db,
+		`WHERE w.latest = 1 AND w.when_epoch >= ? AND w.when_epoch < ?`+where+` ORDER BY w.when_epoch`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	worklogs := []*model.Work{}
+	for _, wl := range found {
+		if matches(wl) {
+			worklogs = append(worklogs, wl)
+		}
+	}
+	return worklogs, nil
+}
+
+func (r *sqliteRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
+	db, openErr := r.openReadOnly()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return nil, openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	found, err := queryWork(db, `WHERE w.latest = 1 AND w.id LIKE ? ESCAPE '\'`, sqliteContains(ID))
+	if err != nil {
+		return nil, err
+	} else if len(found) > 1 {
+		return nil, errors.New(e.RepoGetSingleFileAmbiguous)
+	} else if len(found) == 0 || !matches(found[0]) {
+		return nil, nil
+	}
+	return found[0], nil
+}
+
+func (r *sqliteRepo) GetRevisions(ID string) ([]*model.Work, error) {
+	db, openErr := r.openReadOnly()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return nil, openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	found, err := queryWork(db, `WHERE w.latest = 1 AND w.id LIKE ? ESCAPE '\'`, sqliteContains(ID))
+	if err != nil {
+		return nil, err
+	} else if len(found) > 1 {
+		return nil, errors.New(e.RepoGetSingleFileAmbiguous)
+	} else if len(found) == 0 {
+		return []*model.Work{}, nil
+	}
+	return queryWork(db, `WHERE w.id = ? ORDER BY w.revision`, found[0].ID)
+}
+
+func (r *sqliteRepo) GetAll() ([]*model.Work, error) {
+	db, openErr := r.openReadOnly()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return nil, openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	return queryWork(db, `ORDER BY w.id, w.revision`)
+}
+
+func (r *sqliteRepo) SaveTimer(wl *model.Work) error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	b, err := json.Marshal(wl)
+	if err != nil {
+		return err
+	}
+	if _, err := db.Exec(`INSERT OR REPLACE INTO timer (key, work) VALUES (?, ?)`, timerKey, string(b)); err != nil {
+		helpers.LogError(fmt.Sprintf("Error saving timer: %s", err.Error()), "save timer error - sqlite")
+		return err
+	}
+	return nil
+}
+
+func (r *sqliteRepo) GetTimer() (*model.Work, error) {
+	// Nothing has been saved yet, so no timer is running
+	if _, err := os.Stat(r.path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	db, openErr := r.openReadOnly()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return nil, openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	var raw string
+	err := db.QueryRow(`SELECT work FROM timer WHERE key = ?`, timerKey).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var wl model.Work
+	if err := json.Unmarshal([]byte(raw), &wl); err != nil {
+		return nil, err
+	}
+	wl.Sanitize()
+	return &wl, nil
+}
+
+func (r *sqliteRepo) DeleteTimer() error {
+	db, openErr := r.openReadWrite()
+	if openErr != nil {
+		helpers.LogError(fmt.Sprintf("Error opening file: %s", openErr.Error()), "read db error - sqlite")
+		return openErr
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	if _, err := db.Exec(`DELETE FROM timer WHERE key = ?`, timerKey); err != nil {
+		helpers.LogError(fmt.Sprintf("Error deleting timer: %s", err.Error()), "delete timer error - sqlite")
+		return err
+	}
+	return nil
+}
+
+// Internal wrapped function to ensure all usages are aligned.
+// The schema is created if it doesn't already exist.
+func (r *sqliteRepo) openReadWrite() (*sql.DB, error) {
+	db, err := sql.Open("sqlite", r.path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(1000)")
+	if err != nil {
+		return nil, err
+	}
+	if err := migrateSQLite(db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// Internal wrapped function to ensure all usages are aligned.
+// Databases created with an earlier schema are opened to be
+// written to instead, to migrate them first.
+func (r *sqliteRepo) openReadOnly() (*sql.DB, error) {
+	if _, err := os.Stat(r.path); err != nil {
+		return nil, errors.New(e.RepoGetFilesRead)
+	}
+	db, err := sql.Open("sqlite", "file:"+r.path+"?mode=ro&_pragma=busy_timeout(1000)")
+	if err != nil {
+		return nil, err
+	}
+	version, err := sqliteVersion(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	} else if version < sqliteSchemaVersion {
+		_ = db.Close()
+		return r.openReadWrite()
+	}
+	return db, nil
+}
+
+// sqliteVersion the version of the schema of the database
+func sqliteVersion(db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRow(`PRAGMA user_version`).Scan(&version)
+	return version, err
+}
+
+// migrateSQLite creates the schema, or updates databases created
+// with an earlier schema to the current version
+func migrateSQLite(db *sql.DB) error {
+	if version, err := sqliteVersion(db); err != nil {
+		return err
+	} else if version >= sqliteSchemaVersion {
+		return nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	if _, err := tx.Exec(sqliteSchema); err != nil {
+		return err
+	}
+	var hasTagKey bool
+	if err := tx.QueryRow(`SELECT COUNT(*) > 0 FROM pragma_table_info('tags') WHERE name = 'tag_key'`).
+		Scan(&hasTagKey); err != nil {
+		return err
+	} else if !hasTagKey {
+		if _, err := tx.Exec(`ALTER TABLE tags ADD COLUMN tag_key TEXT NOT NULL DEFAULT ''`); err != nil {
+			return err
+		}
+	}
+	// Lowercased the same as filters, which sqlite's lower() only does for ascii
+	if err := lowercaseTagKeys(tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DROP INDEX IF EXISTS tags_tag;
+		CREATE INDEX IF NOT EXISTS tags_tag_key ON tags (tag_key)`); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, sqliteSchemaVersion)); err != nil {
+		return err
+	}
+	helpers.LogDebug(fmt.Sprintf("migrated database to version %d", sqliteSchemaVersion), "update db - sqlite")
+	return tx.Commit()
+}
+
+// lowercaseTagKeys sets the key of tags which don't have one yet
+func lowercaseTagKeys(tx *sql.Tx) error {
+	rows, err := tx.Query(`SELECT rowid, tag FROM tags WHERE tag_key = '' AND tag != ''`)
+	if err != nil {
+		return err
+	}
+	keys := map[int64]string{}
+	for rows.Next() {
+		var rowID int64
+		var tag string
+		if err := rows.Scan(&rowID, &tag); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		keys[rowID] = strings.ToLower(tag)
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	} else if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for rowID, key := range keys {
+		if _, err := tx.Exec(`UPDATE tags SET tag_key = ? WHERE rowid = ?`, key, rowID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryWork finds the work matching the clause, which follows the
+// selection of work
+func queryWork(db *sql.DB, clause string, args ...any) ([]*model.Work, error) {
+	rows, err := db.Query(sqliteSelect+" "+clause, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	found := []*model.Work{}
+	for rows.Next() {
+		var wl model.Work
+		var when, createdAt, tags string
+		if err := rows.Scan(&wl.ID, &wl.Revision, &wl.Title, &wl.Description, &wl.Author, &wl.Duration,
+			&when, &wl.WhenQueryEpoch, &createdAt, &wl.Deleted, &wl.Source, &tags); err != nil {
+			return nil, err
+		}
+		if wl.When, err = time.Parse(time.RFC3339Nano, when); err != nil {
+			return nil, err
+		}
+		if wl.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(tags), &wl.Tags); err != nil {
+			return nil, err
+		} else if len(wl.Tags) == 0 {
+			wl.Tags = nil
+		}
+		wl.Sanitize()
+		found = append(found, &wl)
+	}
+	return found, rows.Err()
+}
+
+// sqliteTagFilter the conditions matching work which has every tag
+// of the filter, using the index of lowercased tags. Other fields of
+// the filter are matched after querying, as expressions.
+func sqliteTagFilter(filter *model.Work) (string, []any) {
+	if filter == nil {
+		return "", nil
+	}
+	var where strings.Builder
+	args := []any{}
+	for _, tag := range filter.Tags {
+		if tag == "" {
+			continue
+		}
+		tag = strings.ToLower(tag)
+		where.WriteString(` AND (w.id, w.revision) IN (SELECT t.id, t.revision FROM tags t WHERE `)
+		prefix, ok := strings.CutSuffix(tag, helpers.TagPrefix)
+		if !ok {
+			where.WriteString(`t.tag_key = ?)`)
+			args = append(args, tag)
+		} else if end, bounded := sqlitePrefixEnd(prefix); bounded {
+			where.WriteString(`t.tag_key >= ? AND t.tag_key < ?)`)
+			args = append(args, prefix, end)
+		} else {
+			where.WriteString(`t.tag_key >= ?)`)
+			args = append(args, prefix)
+		}
+	}
+	return where.String(), args
+}
+
+// sqliteContains the pattern matching values containing the value
+func sqliteContains(value string) string {
+	return "%" + sqliteLikeEscaper.Replace(value) + "%"
+}
+
+// sqlitePrefixEnd the first value after every value starting with the
+// prefix, or false if there isn't one
+func sqlitePrefixEnd(prefix string) (string, bool) {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1]), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteRepositoryTagIndex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worklog.sqlite")
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+
+	// Saved before tags were indexed lowercased
+	db, err := sql.Open("sqlite", path)
+	assert.Nil(t, err)
+	_, err = db.Exec(strings.Replace(sqliteSchema, "	tag_key  TEXT    NOT NULL DEFAULT '',\n", "", 1) +
+		`CREATE INDEX tags_tag ON tags (tag)`)
+	assert.Nil(t, err)
+	_, err = db.Exec(`INSERT INTO worklogs (id, revision, latest, title, when_at, when_epoch, created_at)
+		VALUES ('abc', 1, 1, 'Before', ?, ?, ?)`,
+		when.Format(time.RFC3339Nano), when.Unix(), when.Format(time.RFC3339Nano))
+	assert.Nil(t, err)
+	_, err = db.Exec(`INSERT INTO tags (id, revision, position, tag) VALUES ('abc', 1, 0, 'Golang')`)
+	assert.Nil(t, err)
+	assert.Nil(t, db.Close())
+
+	r := NewSQLiteRepo(path)
+	found := between(t, r, time.Time{}, &model.Work{Tags: []string{"golang"}})
+	assert.Len(t, found, 1, "migrated when first read")
+	after := model.NewWork("After", "", "Alice", 30, []string{"GO"}, when.Add(time.Hour))
+	assert.Nil(t, r.Save(after))
+
+	for _, testItem := range []struct {
+		tags   []string
+		expLen int
+	}{
+		{tags: []string{"golang"}, expLen: 1},
+		{tags: []string{"go"}, expLen: 1},
+		{tags: []string{"Go*"}, expLen: 2},
+		{tags: []string{"*"}, expLen: 2},
+		{tags: []string{"lang*"}, expLen: 0},
+	} {
+		found := between(t, r, time.Time{}, &model.Work{Tags: testItem.tags})
+		assert.Len(t, found, testItem.expLen, "tags %v", testItem.tags)
+	}
+	found = between(t, r, time.Time{}, &model.Work{Tags: []string{"golang"}})
+	assert.Equal(t, []string{"Golang"}, found[0].Tags, "tags keep their case")
+
+	db, err = sql.Open("sqlite", path)
+	assert.Nil(t, err)
+	defer func() {
+		assert.Nil(t, db.Close())
+	}()
+	where, args := sqliteTagFilter(&model.Work{Tags: []string{"go*"}})
+	var plan strings.Builder
+	rows, err := db.Query(`EXPLAIN QUERY PLAN `+sqliteSelect+` WHERE 1 = 1`+where, args...)
+	assert.Nil(t, err)
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		assert.Nil(t, rows.Scan(&id, &parent, &unused, &detail))
+		plan.WriteString(detail + "\n")
+	}
+	assert.Nil(t, rows.Close())
+	assert.Contains(t, plan.String(), "USING INDEX tags_tag_key (tag_key>? AND tag_key<?)")
+}
+
+func TestSQLiteRepositoryReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worklog.sqlite")
+	r := NewSQLiteRepo(path).(*sqliteRepo)
+	_, err := r.GetAll()
+	assert.NotNil(t, err, "reading doesn't create the database")
+	assert.NoFileExists(t, path)
+
+	assert.Nil(t, r.Init())
+	db, err := r.openReadOnly()
+	assert.Nil(t, err)
+	defer func() {
+		assert.Nil(t, db.Close())
+	}()
+	_, err = db.Exec(`DELETE FROM timer`)
+	assert.ErrorContains(t, err, "readonly")
+}
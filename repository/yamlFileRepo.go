@@ -15,34 +15,41 @@ import (
 	"github.com/PossibleLlama/worklog/model"
 )
 
-var configDir string
-
-const configFileName = "config.yml"
+const (
+	configFileName = "config.yml"
+	timerFileName  = "timer.yml"
+)
 
-type yamlFileRepo struct{}
+type yamlFileRepo struct {
+	dir string
+}
 
 // NewYamlFileRepo Generator for repository storing worklogs
-// on the fs, in a yaml format
-func NewYamlFileRepo() WorklogRepository {
-	return &yamlFileRepo{}
+// on the fs within the given directory, in a yaml format
+func NewYamlFileRepo(dir string) WorklogRepository {
+	return &yamlFileRepo{dir: dir + string(filepath.Separator)}
 }
 
 // NewYamlConfig Generator for configuration repository
 // in a yaml format
 func NewYamlConfig(dir string) ConfigRepository {
-	configDir = dir + string(filepath.Separator)
-	return &yamlFileRepo{}
+	return &yamlFileRepo{dir: dir + string(filepath.Separator)}
+}
+
+func (r *yamlFileRepo) Init() error {
+	return createDirectory(r.dir)
 }
 
-func (*yamlFileRepo) Init() error {
+// Close has nothing to close, as each file is only opened while in use
+func (*yamlFileRepo) Close() error {
 	return nil
 }
 
-func (*yamlFileRepo) SaveConfig(cfg *model.Config) error {
-	if err := createDirectory(configDir); err != nil {
-		return fmt.Errorf("%s %s. %s", e.RepoCreateDirectory, configDir, err.Error())
+func (r *yamlFileRepo) SaveConfig(cfg *model.Config) error {
+	if err := createDirectory(r.dir); err != nil {
+		return fmt.Errorf("%s %s. %s", e.RepoCreateDirectory, r.dir, err.Error())
 	}
-	file, err := createFile(configFileName)
+	file, err := r.createFile(configFileName)
 	if err != nil {
 		return fmt.Errorf("%s. %s", e.RepoConfigFileCreate, err.Error())
 	}
@@ -62,10 +69,19 @@ func (*yamlFileRepo) SaveConfig(cfg *model.Config) error {
 	return nil
 }
 
-func (*yamlFileRepo) Save(wl *model.Work) error {
+func (r *yamlFileRepo) Save(wl *model.Work) error {
 	helpers.LogDebug("Saving file...", "save model - yaml")
 
-	file, err := createFile(generateFileName(wl))
+	if _, err := filePath(r.dir, generateFileName(wl)); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	// The file name includes when the work was done, so
+	// replacing a revision may need the old file removing
+	if err := r.removeRevisionFiles(wl); err != nil {
+		return err
+	}
+
+	file, err := r.createFile(generateFileName(wl))
 	if err != nil {
 		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
 	}
@@ -86,6 +102,64 @@ func (*yamlFileRepo) Save(wl *model.Work) error {
 	return nil
 }
 
+func (r *yamlFileRepo) Delete(id string) error {
+	helpers.LogDebug("Deleting files...", "delete model - yaml")
+
+	fileNames, err := r.getAllFileNamesForID(id)
+	if err != nil {
+		return err
+	}
+	if len(fileNames) == 0 {
+		return errors.New(e.RepoDeleteNotFound)
+	}
+
+	for _, fileName := range fileNames {
+		if err := os.Remove(fileName); err != nil {
+			return fmt.Errorf("%s %s. %s", e.RepoDeleteFile, fileName, err.Error())
+		}
+	}
+
+	helpers.LogDebug("Deleted files", "delete model successful - yaml")
+	return nil
+}
+
+func (r *yamlFileRepo) SaveTimer(wl *model.Work) error {
+	file, err := r.createFile(timerFileName)
+	if err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			helpers.LogError(fmt.Sprintf("Error closing file: %s", err.Error()), "save timer error - yaml")
+		}
+	}()
+
+	if err := wl.WriteYAML(file); err != nil {
+		return fmt.Errorf("%s. %s", e.RepoSaveFile, err.Error())
+	}
+	return file.Sync()
+}
+
+func (r *yamlFileRepo) GetTimer() (*model.Work, error) {
+	if _, err := os.Stat(r.dir + timerFileName); os.IsNotExist(err) {
+		return nil, nil
+	}
+	wl, err := parseFileToWork(r.dir + timerFileName)
+	if err != nil {
+		return nil, err
+	}
+	wl.Sanitize()
+	return wl, nil
+}
+
+func (r *yamlFileRepo) DeleteTimer() error {
+	err := os.Remove(r.dir + timerFileName)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("%s %s. %s", e.RepoDeleteFile, timerFileName, err.Error())
+	}
+	return nil
+}
+
 func generateFileName(wl *model.Work) string {
 	fileName := fmt.Sprintf("%d-%02d-%02dT%02d:%02d_%d_%s",
 		wl.When.Year(),
@@ -109,9 +183,27 @@ func createDirectory(filePath string) error {
 	return nil
 }
 
-func createFile(fileName string) (*os.File, error) {
-	// #nosec G304 -- The variables passed in are either constants or generated by other parts of the codebase
-	file, err := os.Create(configDir + fileName)
+// filePath of the file within the directory. Names which aren't a
+// single file, such as IDs containing path separators or "..", are
+// refused so nothing is written outside of the directory.
+func filePath(dir, fileName string) (string, error) {
+	if fileName == "" || strings.ContainsAny(fileName, `/\`) || strings.Contains(fileName, "..") {
+		return "", fmt.Errorf("%s '%s'", e.RepoFileName, fileName)
+	}
+	path := filepath.Join(dir, fileName)
+	if rel, err := filepath.Rel(dir, path); err != nil || rel != fileName {
+		return "", fmt.Errorf("%s '%s'", e.RepoFileName, fileName)
+	}
+	return path, nil
+}
+
+func (r *yamlFileRepo) createFile(fileName string) (*os.File, error) {
+	path, err := filePath(r.dir, fileName)
+	if err != nil {
+		return nil, err
+	}
+	// #nosec G304 -- The path is checked to be within the directory
+	file, err := os.Create(path)
 	if err != nil {
 		return nil, fmt.Errorf("%s %s. %s", e.RepoCreateFile,
 			fileName, err.Error())
@@ -119,11 +211,15 @@ func createFile(fileName string) (*os.File, error) {
 	return file, nil
 }
 
-func (*yamlFileRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
+func (r *yamlFileRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, error) {
 	var worklogs []*model.Work
 	var errors []string
 
-	fileNames, err := getAllFileNamesBetweenDates(startDate, endDate)
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return worklogs, err
+	}
+	fileNames, err := r.getAllFileNamesBetweenDates(startDate, endDate)
 	if err != nil {
 		return worklogs, err
 	}
@@ -132,7 +228,7 @@ func (*yamlFileRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *mo
 		readWorklog, err := parseFileToWork(fileName)
 		if err != nil {
 			errors = append(errors, err.Error())
-		} else if workMatchesFilter(filter, readWorklog) {
+		} else if matches(readWorklog) {
 			readWorklog.Sanitize()
 			worklogs = append(worklogs, readWorklog)
 		}
@@ -144,11 +240,14 @@ func (*yamlFileRepo) GetAllBetweenDates(startDate, endDate time.Time, filter *mo
 	return worklogs, nil
 }
 
-func (*yamlFileRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
+func (r *yamlFileRepo) GetByID(ID string, filter *model.Work) (*model.Work, error) {
 	var wl *model.Work
-	var err error
 
-	fileName, err := getFileByID(ID)
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return nil, err
+	}
+	fileName, err := r.getFileByID(ID)
 	if err != nil {
 		return nil, err
 	}
@@ -159,17 +258,48 @@ func (*yamlFileRepo) GetByID(ID string, filter *model.Work) (*model.Work, error)
 	wl, err = parseFileToWork(fileName)
 	if err != nil {
 		return nil, err
-	} else if workMatchesFilter(filter, wl) {
+	} else if matches(wl) {
 		wl.Sanitize()
 		return wl, nil
 	}
 	return nil, nil
 }
 
-func getAllFileNamesBetweenDates(startDate, endDate time.Time) ([]string, error) {
+func (r *yamlFileRepo) GetRevisions(ID string) ([]*model.Work, error) {
+	revisions := []*model.Work{}
+
+	fileName, err := r.getFileByID(ID)
+	if err != nil {
+		return nil, err
+	}
+	if fileName == "" {
+		return revisions, nil
+	}
+
+	splitFileName := strings.Split(filepath.Base(fileName), "_")
+	fileNames, err := r.getAllFileNamesForID(strings.TrimSuffix(splitFileName[2], ".yml"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, revisionFileName := range fileNames {
+		wl, err := parseFileToWork(revisionFileName)
+		if err != nil {
+			return nil, err
+		}
+		wl.Sanitize()
+		revisions = append(revisions, wl)
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, nil
+}
+
+func (r *yamlFileRepo) getAllFileNamesBetweenDates(startDate, endDate time.Time) ([]string, error) {
 	var files []string
 
-	err := filepath.Walk(configDir, func(fullPath string, info os.FileInfo, walkErr error) error {
+	err := filepath.Walk(r.dir, func(fullPath string, info os.FileInfo, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
@@ -194,11 +324,11 @@ func getAllFileNamesBetweenDates(startDate, endDate time.Time) ([]string, error)
 	return files, err
 }
 
-func (*yamlFileRepo) GetAll() ([]*model.Work, error) {
+func (r *yamlFileRepo) GetAll() ([]*model.Work, error) {
 	var all []*model.Work
 	var errors []string
 
-	fileNames, err := getAllFileNames()
+	fileNames, err := r.getAllFileNames()
 	if err != nil {
 		return all, err
 	}
@@ -220,10 +350,10 @@ func (*yamlFileRepo) GetAll() ([]*model.Work, error) {
 	return all, nil
 }
 
-func getAllFileNames() ([]string, error) {
+func (r *yamlFileRepo) getAllFileNames() ([]string, error) {
 	var files []string
 
-	err := filepath.Walk(configDir, func(fullPath string, info os.FileInfo, walkErr error) error {
+	err := filepath.Walk(r.dir, func(fullPath string, info os.FileInfo, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
@@ -239,10 +369,52 @@ func getAllFileNames() ([]string, error) {
 	return files, err
 }
 
-func getFileByID(ID string) (string, error) {
+// getAllFileNamesForID finds every revision stored for the exact ID
+func (r *yamlFileRepo) getAllFileNamesForID(ID string) ([]string, error) {
+	var files []string
+
+	err := filepath.Walk(r.dir, func(fullPath string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		path := filepath.Base(fullPath)
+		if strings.Count(path, "_") < 2 {
+			return nil
+		}
+
+		splitFileName := strings.Split(path, "_")
+		if strings.TrimSuffix(splitFileName[2], ".yml") == ID {
+			files = append(files, fullPath)
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// removeRevisionFiles removes any other files for the same revision of work
+func (r *yamlFileRepo) removeRevisionFiles(wl *model.Work) error {
+	fileNames, err := r.getAllFileNamesForID(wl.ID)
+	if err != nil {
+		return err
+	}
+	for _, fileName := range fileNames {
+		base := filepath.Base(fileName)
+		if base == generateFileName(wl) ||
+			strings.Split(base, "_")[1] != strconv.Itoa(wl.Revision) {
+			continue
+		}
+		if err := os.Remove(fileName); err != nil {
+			return fmt.Errorf("%s %s. %s", e.RepoDeleteFile, fileName, err.Error())
+		}
+	}
+	return nil
+}
+
+func (r *yamlFileRepo) getFileByID(ID string) (string, error) {
 	ids := make(map[string]string)
 
-	err := filepath.Walk(configDir, func(fullPath string, info os.FileInfo, err error) error {
+	err := filepath.Walk(r.dir, func(fullPath string, info os.FileInfo, err error) error {
 		path := filepath.Base(fullPath)
 		if strings.Count(path, "_") < 2 {
 			return nil
@@ -290,22 +462,3 @@ func parseFileToWork(filePath string) (*model.Work, error) {
 	}
 	return worklog, err
 }
-
-func workMatchesFilter(filter, w *model.Work) bool {
-	if !helpers.AInB(filter.Title, w.Title) {
-		return false
-	}
-	if !helpers.AInB(filter.Description, w.Description) {
-		return false
-	}
-	if !helpers.AInB(filter.Author, w.Author) {
-		return false
-	}
-	for _, filtersTag := range filter.Tags {
-		if filtersTag != "" &&
-			!helpers.AInB(filtersTag, strings.Join(w.Tags, " ")) {
-			return false
-		}
-	}
-	return true
-}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+	"github.com/PossibleLlama/worklog/service"
+)
+
+const (
+	benchmarkWorklogs = 200
+	// benchmarkParallelism goroutines for each CPU sending requests,
+	// so requests overlap even with a single CPU
+	benchmarkParallelism = 16
+)
+
+// benchmarkRepos the bolt repositories to compare, opening the
+// file for each request or holding it open
+var benchmarkRepos = []struct {
+	name    string
+	newRepo func(path string) repository.WorklogRepository
+}{
+	{name: "Per request", newRepo: repository.NewBBoltRepo},
+	{name: "Persistent", newRepo: repository.NewPersistentBBoltRepo},
+}
+
+// newBenchmarkHandler serves worklogs stored in a new bolt file,
+// seeded with work, returning the IDs of that work
+func newBenchmarkHandler(b *testing.B, newRepo func(path string) repository.WorklogRepository) (http.Handler, []string) {
+	b.Helper()
+	token = ""
+	wlRepo = newRepo(filepath.Join(b.TempDir(), "worklog.db"))
+	wlService = service.NewWorklogService(wlRepo)
+	b.Cleanup(func() {
+		_ = wlRepo.Close()
+	})
+
+	ids := []string{}
+	when := time.Date(2021, time.March, 1, 9, 0, 0, 0, time.Local)
+	for i := 0; i < benchmarkWorklogs; i++ {
+		wl := model.NewWork(fmt.Sprintf("Work %d", i), "", "Alice", 15, []string{"bench"}, when.Add(time.Duration(i)*time.Hour))
+		if err := wlRepo.Save(wl); err != nil {
+			b.Fatalf("unable to seed worklogs. %s", err.Error())
+		}
+		ids = append(ids, wl.ID)
+	}
+	return newHandler(), ids
+}
+
+// quiet discards what is logged while benchmarking, as
+// every request logs
+func quiet(b *testing.B) {
+	b.Helper()
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	b.Cleanup(func() {
+		os.Stdout = stdout
+		_ = devNull.Close()
+	})
+}
+
+// runParallel sends the request made for each iteration to the handler
+// from parallel goroutines, reporting how many weren't successful
+func runParallel(b *testing.B, handler http.Handler, request func(i int64) *http.Request) {
+	b.Helper()
+	var count, failed int64
+	b.ReportAllocs()
+	b.SetParallelism(benchmarkParallelism)
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			resp := httptest.NewRecorder()
+			handler.ServeHTTP(resp, request(atomic.AddInt64(&count, 1)))
+			if resp.Code >= http.StatusBadRequest {
+				atomic.AddInt64(&failed, 1)
+			}
+		}
+	})
+	b.ReportMetric(float64(failed), "failed")
+}
+
+func BenchmarkParallelGetByID(b *testing.B) {
+	for _, bench := range benchmarkRepos {
+		b.Run(bench.name, func(b *testing.B) {
+			quiet(b)
+			handler, ids := newBenchmarkHandler(b, bench.newRepo)
+
+			runParallel(b, handler, func(i int64) *http.Request {
+				return httptest.NewRequest(http.MethodGet, PATH+"/"+ids[i%int64(len(ids))], nil)
+			})
+		})
+	}
+}
+
+func BenchmarkParallelGetBetweenDates(b *testing.B) {
+	for _, bench := range benchmarkRepos {
+		b.Run(bench.name, func(b *testing.B) {
+			quiet(b)
+			handler, _ := newBenchmarkHandler(b, bench.newRepo)
+
+			runParallel(b, handler, func(int64) *http.Request {
+				return httptest.NewRequest(http.MethodGet, PATH+"?startDate=2021-03-02&endDate=2021-03-04", nil)
+			})
+		})
+	}
+}
+
+// BenchmarkParallelMixed creates work for every tenth request,
+// while the others get work by its ID
+func BenchmarkParallelMixed(b *testing.B) {
+	benchmarkMixed(b, 10)
+}
+
+// BenchmarkParallelMixedWriteHeavy creates work for every other
+// request. Opening for each request, writers wait on the file lock
+// held by readers and other writers, and fail after a second.
+func BenchmarkParallelMixedWriteHeavy(b *testing.B) {
+	benchmarkMixed(b, 2)
+}
+
+// benchmarkMixed creates work for every writeEvery request,
+// while the others get work by its ID
+func benchmarkMixed(b *testing.B, writeEvery int64) {
+	for _, bench := range benchmarkRepos {
+		b.Run(bench.name, func(b *testing.B) {
+			quiet(b)
+			handler, ids := newBenchmarkHandler(b, bench.newRepo)
+			body, _ := json.Marshal(model.NewWork("Created", "", "Bob", 30, []string{"bench"}, time.Now()))
+
+			runParallel(b, handler, func(i int64) *http.Request {
+				if i%writeEvery == 0 {
+					return httptest.NewRequest(http.MethodPost, PATH, bytes.NewReader(body))
+				}
+				return httptest.NewRequest(http.MethodGet, PATH+"/"+ids[i%int64(len(ids))], nil)
+			})
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+func Calendar(resp http.ResponseWriter, req *http.Request) {
+	startDate, endDate, filter := parsePrintQuery(req)
+
+	helpers.LogDebug(fmt.Sprintf("Getting calendar from %v, to %v, with filter %+v", startDate, endDate, filter), "calendar")
+
+	ret, status, err := wlService.GetWorklogsBetween(startDate, endDate, filter)
+	if err != nil {
+		resp.WriteHeader(status)
+		helpers.LogError(fmt.Sprintf("failed to find work. %s", err.Error()), "calendar")
+		return
+	}
+
+	// Subscribed calendars expect a calendar, even when it has no events
+	resp.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	resp.WriteHeader(http.StatusOK)
+	err = model.WriteAllWorkToICS(resp, ret)
+	if err != nil {
+		helpers.LogError("failed to encode calendar", "calendar")
+		return
+	}
+}
@@ -0,0 +1,24 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/PossibleLlama/worklog/helpers"
+
+	"github.com/gorilla/mux"
+)
+
+func Delete(resp http.ResponseWriter, req *http.Request) {
+	purge, err := strconv.ParseBool(req.URL.Query().Get("purge"))
+	if err != nil {
+		purge = false
+	}
+
+	status, err := wlService.DeleteWorklog(mux.Vars(req)["id"], purge)
+	resp.WriteHeader(status)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to delete work. %s", err.Error()), "delete")
+	}
+}
@@ -14,6 +14,27 @@ import (
 )
 
 func Print(resp http.ResponseWriter, req *http.Request) {
+	startDate, endDate, filter := parsePrintQuery(req)
+
+	helpers.LogDebug(fmt.Sprintf("Getting worklogs from %v, to %v, with filter %+v", startDate, endDate, filter), "print")
+
+	ret, status, err := wlService.GetWorklogsBetween(startDate, endDate, filter)
+	resp.WriteHeader(status)
+
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to find work. %s", err.Error()), "print")
+		return
+	}
+	err = json.NewEncoder(resp).Encode(ret)
+	if err != nil {
+		helpers.LogError("failed to encode work", "print")
+		return
+	}
+}
+
+// parsePrintQuery the dates and filter to find work with,
+// from the query parameters of the request
+func parsePrintQuery(req *http.Request) (time.Time, time.Time, *model.Work) {
 	var startDate, endDate time.Time
 	var err error
 	startDateString := req.URL.Query().Get("startDate")
@@ -48,34 +69,35 @@ func Print(resp http.ResponseWriter, req *http.Request) {
 		Author:      req.URL.Query().Get("author"),
 		Tags:        tags,
 	}
+	return startDate, endDate, &filter
+}
 
-	helpers.LogDebug(fmt.Sprintf("Getting worklogs from %v, to %v, with filter %+v", startDate, endDate, filter), "print")
-
-	ret, status, err := wlService.GetWorklogsBetween(startDate, endDate, &filter)
+func PrintSingle(resp http.ResponseWriter, req *http.Request) {
+	ret, status, err := wlService.GetWorklogsByID(&model.Work{}, mux.Vars(req)["id"])
 	resp.WriteHeader(status)
 
 	if err != nil {
 		helpers.LogError(fmt.Sprintf("failed to find work. %s", err.Error()), "print")
 		return
+	} else if len(ret) == 0 {
+		return
 	}
-	err = json.NewEncoder(resp).Encode(ret)
+	err = json.NewEncoder(resp).Encode(ret[0])
 	if err != nil {
 		helpers.LogError("failed to encode work", "print")
 		return
 	}
 }
 
-func PrintSingle(resp http.ResponseWriter, req *http.Request) {
-	ret, status, err := wlService.GetWorklogsByID(&model.Work{}, mux.Vars(req)["id"])
+func PrintRevisions(resp http.ResponseWriter, req *http.Request) {
+	ret, status, err := wlService.GetWorklogRevisions(mux.Vars(req)["id"])
 	resp.WriteHeader(status)
 
 	if err != nil {
-		helpers.LogError(fmt.Sprintf("failed to find work. %s", err.Error()), "print")
-		return
-	} else if len(ret) == 0 {
+		helpers.LogError(fmt.Sprintf("failed to find revisions. %s", err.Error()), "print")
 		return
 	}
-	err = json.NewEncoder(resp).Encode(ret[0])
+	err = json.NewEncoder(resp).Encode(ret)
 	if err != nil {
 		helpers.LogError("failed to encode work", "print")
 		return
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+	"github.com/PossibleLlama/worklog/service"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer serves worklogs held in memory, requiring the token if given
+func newTestServer(t *testing.T, serverToken string) *httptest.Server {
+	token = serverToken
+	wlRepo = repository.NewMemoryRepo("")
+	wlService = service.NewWorklogService(wlRepo)
+	ts := httptest.NewServer(newHandler())
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestRemoteRepository(t *testing.T) {
+	ts := newTestServer(t, "secret")
+	remote := repository.NewRemoteRepo(ts.URL+"/", "secret")
+	when := time.Date(2021, time.March, 1, 9, 0, 0, 0, time.Local)
+
+	wl := model.NewWork("Fish & chips", "Lunch", "Alice", 30, []string{"food", "lunch"}, when)
+	wl.Sanitize()
+	assert.Nil(t, remote.Save(wl))
+
+	found, err := remote.GetByID(wl.ID[:6], &model.Work{})
+	assert.Nil(t, err)
+	assert.Equal(t, wl.ID, found.ID, "work keeps its ID")
+	assert.Equal(t, 1, found.Revision)
+	assert.Equal(t, "Fish &amp; chips", found.Title, "escaped text isn't escaped twice")
+
+	found.Update(model.Work{Title: "Fish and chips"})
+	assert.Nil(t, remote.Save(found))
+	assert.Equal(t, 2, found.Revision)
+
+	other := model.NewWork("Standup", "", "Bob", 15, []string{"meeting"}, when.Add(time.Hour))
+	assert.Nil(t, remote.Save(other))
+
+	between, err := remote.GetAllBetweenDates(when, when.Add(time.Minute), &model.Work{Tags: []string{"food"}})
+	assert.Nil(t, err)
+	assert.Len(t, between, 1)
+	assert.Equal(t, "Fish and chips", between[0].Title)
+
+	none, err := remote.GetAllBetweenDates(when, when.Add(time.Minute), &model.Work{Author: "Bob"})
+	assert.Nil(t, err)
+	assert.Empty(t, none)
+
+	filtered, err := remote.GetByID(wl.ID, &model.Work{Author: "Bob"})
+	assert.Nil(t, err)
+	assert.Nil(t, filtered)
+
+	revs, err := remote.GetRevisions(wl.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revs, 2)
+
+	all, err := remote.GetAll()
+	assert.Nil(t, err)
+	assert.Len(t, all, 3)
+
+	other.MarkDeleted()
+	assert.Nil(t, remote.Save(other))
+	deleted, err := remote.GetByID(other.ID, &model.Work{})
+	assert.Nil(t, err)
+	assert.True(t, deleted.Deleted)
+
+	assert.Nil(t, remote.Delete(other.ID))
+	assert.Equal(t, errors.New(e.RepoDeleteNotFound), remote.Delete(other.ID))
+	missing, err := remote.GetByID(other.ID, &model.Work{})
+	assert.Nil(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestRemoteRepositoryImportTwice(t *testing.T) {
+	ts := newTestServer(t, "")
+	remote := repository.NewRemoteRepo(ts.URL, "")
+	when := time.Date(2021, time.March, 1, 9, 0, 0, 0, time.Local)
+
+	// Imported the same as a calendar event, with an ID from its source.
+	// Importing again skips work with the same ID or source, so both
+	// must be kept, while overwriting saves the same revision again.
+	imported := func() *model.Work {
+		wl := model.NewWork("Standup", "", "Alice", 15, []string{"meeting"}, when)
+		wl.ID = "e4d909c290d0fb1ca068"
+		wl.Source = "ics:standup@example.com"
+		return wl
+	}
+	assert.Nil(t, remote.Save(imported()))
+	assert.Nil(t, remote.Save(imported()))
+
+	all, err := remote.GetAll()
+	assert.Nil(t, err)
+	assert.Len(t, all, 1)
+	assert.Equal(t, "e4d909c290d0fb1ca068", all[0].ID)
+	assert.Equal(t, "ics:standup@example.com", all[0].Source)
+
+	changed := imported()
+	changed.Title = "Retro"
+	err = remote.Save(changed)
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), e.RepoSaveFile))
+}
+
+func TestRemoteRepositoryErrors(t *testing.T) {
+	ts := newTestServer(t, "secret")
+
+	_, err := repository.NewRemoteRepo(ts.URL, "wrong").GetAll()
+	assert.Equal(t, errors.New(e.RepoRemoteUnauthorized), err)
+
+	_, err = repository.NewRemoteRepo("", "").GetAll()
+	assert.Equal(t, errors.New(e.RepoRemoteURL), err)
+
+	unreachable := ts.URL
+	ts.Close()
+	_, err = repository.NewRemoteRepo(unreachable, "secret").GetAll()
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), e.RepoRemoteUnreachable+" "+unreachable))
+}
+
+func TestRemoteRepositoryReplica(t *testing.T) {
+	ts := newTestServer(t, "")
+	remote := repository.NewRemoteRepo(ts.URL, "").(repository.ReplicaRepository)
+	wl := model.NewWork("Offline", "", "Alice", 60, nil, time.Date(2021, time.March, 1, 9, 0, 0, 0, time.Local))
+	edited := *wl
+	edited.Update(model.Work{Title: "Offline edit"})
+
+	assert.Nil(t, remote.SaveRevisions([]*model.Work{wl, &edited}))
+
+	revs, err := remote.RevisionsAfter(map[string]int{})
+	assert.Nil(t, err)
+	assert.Len(t, revs, 2)
+	assert.Equal(t, wl.ID, revs[0].ID, "revisions keep their ID")
+	revs, err = remote.RevisionsAfter(map[string]int{wl.ID: 1})
+	assert.Nil(t, err)
+	assert.Len(t, revs, 1)
+	assert.Equal(t, "Offline edit", revs[0].Title)
+
+	conflicting := edited
+	conflicting.Title = "Conflicting edit"
+	err = remote.SaveRevisions([]*model.Work{&conflicting})
+	assert.Equal(t, errors.New(e.RepoSyncConflict+": "+wl.ID), err)
+}
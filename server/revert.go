@@ -0,0 +1,44 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/PossibleLlama/worklog/helpers"
+
+	"github.com/gorilla/mux"
+)
+
+type revertBody struct {
+	Revision int `json:"revision"`
+}
+
+func Revert(resp http.ResponseWriter, req *http.Request) {
+	var body revertBody
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil || body.Revision < 1 {
+		helpers.LogError("error decoding body into revision", "revert")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	// #nosec CWE-703 -- From my understanding, IO errors can occur, which are potentially an issue during file IO.
+	// I haven't seen a similar example of harm to a network IO so will ignore for now.
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	new, status, err := wlService.RevertWorklog(mux.Vars(req)["id"], body.Revision)
+	resp.WriteHeader(status)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to revert work. %s", err.Error()), "revert")
+		return
+	} else if new == nil {
+		return
+	}
+	err = json.NewEncoder(resp).Encode(new)
+	if err != nil {
+		helpers.LogError("failed to encode work", "revert")
+	}
+}
@@ -25,12 +25,25 @@ import (
 const (
 	PATH    = "/worklog"
 	ID_PATH = PATH + "/{id}"
+
+	SUMMARY_PATH = PATH + "/summary"
+	STANDUP_PATH = PATH + "/standup"
+
+	CALENDAR_PATH = PATH + "/calendar.ics"
+
+	SYNC_PULL_PATH = PATH + "/sync/pull"
+	SYNC_PUSH_PATH = PATH + "/sync/push"
+
+	TIMER_PATH      = PATH + "/timer"
+	TIMER_STOP_PATH = TIMER_PATH + "/stop"
+
+	REVISIONS_PATH = ID_PATH + "/revisions"
+	REVERT_PATH    = ID_PATH + "/revert"
 )
 
 var (
-	httpRouter *mux.Router = mux.NewRouter().StrictSlash(true)
-	wlService  service.WorklogService
-	wlRepo     repository.WorklogRepository
+	wlService service.WorklogService
+	wlRepo    repository.WorklogRepository
 )
 
 var (
@@ -39,6 +52,8 @@ var (
 	repoType     string
 	repoLocation string
 	port         int
+	token        string
+	persistent   bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -56,11 +71,12 @@ For information on using the CLI, use worklog-server
 
 func Execute() {
 	InitCobra()
-	InitConfig()
+	// Flags are parsed first, as they override the config
 	if err := rootCmd.Execute(); err != nil {
 		helpers.LogError(err.Error(), "startup")
 		os.Exit(e.StartupErrors)
 	}
+	InitConfig()
 
 	startServer()
 }
@@ -68,31 +84,18 @@ func Execute() {
 func startServer() {
 	helpers.LogInfo(fmt.Sprintf("server starting on port %d\n", port), "startup")
 
-	httpRouter.Use(SetDefaultHeadersMiddleware)
-
-	httpRouter.NotFoundHandler = http.HandlerFunc(NotFound)
-	httpRouter.MethodNotAllowedHandler = http.HandlerFunc(InvalidMethod)
-	httpRouter.HandleFunc(PATH, Create).Methods(http.MethodPost)
-	httpRouter.HandleFunc(PATH, Print).Methods(http.MethodGet)
-	httpRouter.HandleFunc(ID_PATH, PrintSingle).Methods(http.MethodGet)
-	httpRouter.HandleFunc(ID_PATH, Edit).Methods(http.MethodPut)
-
-	c := cors.New(cors.Options{
-		AllowedOrigins: []string{"*"},
-		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut},
-	})
-
 	server := &http.Server{
 		Addr:              fmt.Sprintf("0.0.0.0:%d", port),
 		WriteTimeout:      time.Second * 10,
 		ReadTimeout:       time.Second * 5,
 		ReadHeaderTimeout: time.Second * 5,
 		IdleTimeout:       time.Second * 20,
-		Handler:           c.Handler(httpRouter),
+		Handler:           newHandler(),
 	}
 
 	go func() {
-		if err := server.ListenAndServe(); err != nil {
+		// Closing is expected when shutting down, which continues in interruptAndExit
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			helpers.LogError(fmt.Sprintf("server error occurred '%s'\n", err.Error()), "startup")
 			os.Exit(e.StartupErrors)
 		}
@@ -101,6 +104,42 @@ func startServer() {
 	interruptAndExit(server)
 }
 
+// newHandler routes each path to its handler, requiring
+// the token if one is configured
+func newHandler() http.Handler {
+	httpRouter := mux.NewRouter().StrictSlash(true)
+	httpRouter.Use(SetDefaultHeadersMiddleware)
+	if token != "" {
+		httpRouter.Use(RequireTokenMiddleware)
+	}
+
+	httpRouter.NotFoundHandler = http.HandlerFunc(NotFound)
+	httpRouter.MethodNotAllowedHandler = http.HandlerFunc(InvalidMethod)
+	httpRouter.HandleFunc(PATH, Create).Methods(http.MethodPost)
+	httpRouter.HandleFunc(PATH, Print).Methods(http.MethodGet)
+	// Registered before ID_PATH, so they aren't matched as an ID
+	httpRouter.HandleFunc(SUMMARY_PATH, Summary).Methods(http.MethodGet)
+	httpRouter.HandleFunc(STANDUP_PATH, Standup).Methods(http.MethodGet)
+	httpRouter.HandleFunc(CALENDAR_PATH, Calendar).Methods(http.MethodGet)
+	httpRouter.HandleFunc(SYNC_PULL_PATH, PullRevisions).Methods(http.MethodPost)
+	httpRouter.HandleFunc(SYNC_PUSH_PATH, PushRevisions).Methods(http.MethodPost)
+	httpRouter.HandleFunc(TIMER_PATH, StartTimer).Methods(http.MethodPost)
+	httpRouter.HandleFunc(TIMER_PATH, PrintTimer).Methods(http.MethodGet)
+	httpRouter.HandleFunc(TIMER_STOP_PATH, StopTimer).Methods(http.MethodPost)
+	httpRouter.HandleFunc(ID_PATH, PrintSingle).Methods(http.MethodGet)
+	httpRouter.HandleFunc(ID_PATH, Edit).Methods(http.MethodPut)
+	httpRouter.HandleFunc(ID_PATH, Delete).Methods(http.MethodDelete)
+	httpRouter.HandleFunc(REVISIONS_PATH, PrintRevisions).Methods(http.MethodGet)
+	httpRouter.HandleFunc(REVERT_PATH, Revert).Methods(http.MethodPost)
+
+	c := cors.New(cors.Options{
+		AllowedOrigins: []string{"*"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+	})
+	return c.Handler(httpRouter)
+}
+
 func interruptAndExit(server *http.Server) {
 	interruptChan := make(chan os.Signal, 1)
 	signal.Notify(interruptChan, syscall.SIGINT, syscall.SIGABRT, syscall.SIGTERM)
@@ -111,6 +150,15 @@ func interruptAndExit(server *http.Server) {
 	defer cancel()
 
 	_ = server.Shutdown(ctx)
+
+	if snapshots, ok := wlRepo.(repository.SnapshotRepository); ok {
+		if err := snapshots.Snapshot(); err != nil {
+			helpers.LogError(fmt.Sprintf("unable to snapshot worklogs '%s'", err.Error()), "shutdown")
+		}
+	}
+	if err := wlRepo.Close(); err != nil {
+		helpers.LogError(fmt.Sprintf("unable to close repository '%s'", err.Error()), "shutdown")
+	}
 }
 
 func InitCobra() {
@@ -144,6 +192,14 @@ func InitCobra() {
 		"p",
 		8080,
 		"Port to start the server on")
+	rootCmd.PersistentFlags().StringVar(&token,
+		"token",
+		"",
+		"Token clients must send as a bearer token. Defaults to server.token from the config")
+	rootCmd.PersistentFlags().BoolVar(&persistent,
+		"persistent",
+		false,
+		"Hold a bolt database open, rather than opening it for each request. Defaults to server.persistent from the config")
 }
 
 // initConfig reads in config file and ENV variables if set
@@ -160,15 +216,42 @@ func InitConfig() {
 		helpers.LogError(fmt.Sprintf("Unable to use config file: '%s'. %s", viper.ConfigFileUsed(), err.Error()), "startup - load config")
 	}
 
-	repoLocation = helpers.GetRepoPath(repoLocation, homeDir)
+	if token == "" {
+		token = viper.GetString("server.token")
+	}
+	if !persistent {
+		persistent = viper.GetBool("server.persistent")
+	}
+	repoType = helpers.GetRepoTypeString(repoType)
+	repoLocation = helpers.GetRepoPath(repoType, repoLocation, homeDir)
 
-	switch helpers.GetRepoTypeString(repoType) {
+	switch repoType {
 	case "":
 		fallthrough
-	case "bolt":
+	case helpers.RepoTypeBolt:
 		wlRepo = repository.NewBBoltRepo(repoLocation)
-	case "legacy":
-		wlRepo = repository.NewYamlFileRepo()
+		if persistent {
+			// Held open, rather than opened for each request
+			wlRepo = repository.NewPersistentBBoltRepo(repoLocation)
+		}
+	case helpers.RepoTypeLegacy:
+		wlRepo = repository.NewYamlFileRepo(filepath.Dir(cfgFile))
+	case helpers.RepoTypeSQLite:
+		wlRepo = repository.NewSQLiteRepo(repoLocation)
+	case helpers.RepoTypeJSONL:
+		wlRepo = repository.NewJSONLRepo(repoLocation)
+	case helpers.RepoTypeGit:
+		wlRepo = repository.NewGitRepo(repoLocation)
+	case helpers.RepoTypeMemory:
+		wlRepo = repository.NewMemoryRepo(repoLocation)
+		// Loaded now, rather than on the first request
+		if err := wlRepo.Init(); err != nil {
+			helpers.LogError(fmt.Sprintf("unable to load worklogs '%s'", err.Error()), "startup")
+			os.Exit(e.StartupErrors)
+		}
+	case helpers.RepoTypeRemote:
+		helpers.LogWarn(e.RepoRemoteServer, "startup - remote repo type")
+		os.Exit(e.StartupErrors)
 	default:
 		helpers.LogWarn(e.RootRepoType, "startup - unknown repo type")
 		os.Exit(e.StartupErrors)
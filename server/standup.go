@@ -0,0 +1,49 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+func Standup(resp http.ResponseWriter, req *http.Request) {
+	tags := []string{}
+	for _, t := range strings.Split(req.URL.Query().Get("tags"), ",") {
+		if strings.TrimSpace(t) != "" {
+			tags = append(tags, helpers.Sanitize(strings.TrimSpace(t)))
+		}
+	}
+	filter := model.Work{
+		Author: req.URL.Query().Get("author"),
+		Tags:   tags,
+	}
+
+	ret, status, err := wlService.GetStandup(time.Now(), &filter)
+	if err != nil {
+		resp.WriteHeader(status)
+		helpers.LogError(fmt.Sprintf("failed to find work. %s", err.Error()), "standup")
+		return
+	}
+
+	switch req.URL.Query().Get("format") {
+	case "markdown", "md":
+		resp.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		resp.WriteHeader(status)
+		err = ret.WriteMarkdown(resp)
+	case "text":
+		resp.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		resp.WriteHeader(status)
+		err = ret.WritePrettyText(resp)
+	default:
+		resp.WriteHeader(status)
+		err = ret.WriteJSON(resp)
+	}
+	if err != nil {
+		helpers.LogError("failed to encode standup", "standup")
+		return
+	}
+}
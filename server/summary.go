@@ -0,0 +1,37 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/PossibleLlama/worklog/helpers"
+)
+
+func Summary(resp http.ResponseWriter, req *http.Request) {
+	startDate, endDate, filter := parsePrintQuery(req)
+
+	groupBy := []string{}
+	for _, group := range strings.Split(req.URL.Query().Get("groupBy"), ",") {
+		if strings.TrimSpace(group) != "" {
+			groupBy = append(groupBy, strings.ToLower(strings.TrimSpace(group)))
+		}
+	}
+
+	helpers.LogDebug(fmt.Sprintf("Summarising worklogs from %v, to %v, with filter %+v, grouped by %v",
+		startDate, endDate, filter, groupBy), "summary")
+
+	ret, status, err := wlService.Summarise(startDate, endDate, filter, groupBy)
+	resp.WriteHeader(status)
+
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to summarise work. %s", err.Error()), "summary")
+		return
+	}
+	err = json.NewEncoder(resp).Encode(ret)
+	if err != nil {
+		helpers.LogError("failed to encode summary", "summary")
+		return
+	}
+}
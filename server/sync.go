@@ -0,0 +1,66 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+func PullRevisions(resp http.ResponseWriter, req *http.Request) {
+	marks := make(map[string]int)
+
+	err := json.NewDecoder(req.Body).Decode(&marks)
+	if err != nil {
+		helpers.LogError("error decoding body into revisions synced: "+err.Error(), "pull revisions")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	// #nosec CWE-703 -- From my understanding, IO errors can occur, which are potentially an issue during file IO.
+	// I haven't seen a similar example of harm to a network IO so will ignore for now.
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	revs, status, err := wlService.PullRevisions(marks)
+	resp.WriteHeader(status)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to find revisions. %s", err.Error()), "pull revisions")
+		return
+	}
+	err = json.NewEncoder(resp).Encode(revs)
+	if err != nil {
+		helpers.LogError("failed to encode work", "pull revisions")
+	}
+}
+
+func PushRevisions(resp http.ResponseWriter, req *http.Request) {
+	var body []*model.Work
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		helpers.LogError("error decoding body into revisions: "+err.Error(), "push revisions")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	// #nosec CWE-703 -- From my understanding, IO errors can occur, which are potentially an issue during file IO.
+	// I haven't seen a similar example of harm to a network IO so will ignore for now.
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	conflicts, status, err := wlService.PushRevisions(body)
+	resp.WriteHeader(status)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to save revisions. %s", err.Error()), "push revisions")
+	}
+	// Conflicts are returned, so they can be resolved
+	if conflicts != nil {
+		err = json.NewEncoder(resp).Encode(conflicts)
+		if err != nil {
+			helpers.LogError("failed to encode conflicts", "push revisions")
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+func StartTimer(resp http.ResponseWriter, req *http.Request) {
+	var body model.Work
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		helpers.LogError("error decoding body into work: "+err.Error(), "start timer")
+		resp.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	// #nosec CWE-703 -- From my understanding, IO errors can occur, which are potentially an issue during file IO.
+	// I haven't seen a similar example of harm to a network IO so will ignore for now.
+	defer func() {
+		_ = req.Body.Close()
+	}()
+
+	wl := model.NewWork(
+		body.Title,
+		body.Description,
+		body.Author,
+		0,
+		body.Tags,
+		time.Time{})
+
+	status, err := wlService.StartTimer(wl)
+	resp.WriteHeader(status)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to start timer. %s", err.Error()), "start timer")
+		return
+	}
+	err = json.NewEncoder(resp).Encode(wl)
+	if err != nil {
+		helpers.LogError("failed to encode work", "start timer")
+	}
+}
+
+func StopTimer(resp http.ResponseWriter, req *http.Request) {
+	wl, status, err := wlService.StopTimer()
+	resp.WriteHeader(status)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to stop timer. %s", err.Error()), "stop timer")
+		return
+	} else if wl == nil {
+		return
+	}
+	err = json.NewEncoder(resp).Encode(wl)
+	if err != nil {
+		helpers.LogError("failed to encode work", "stop timer")
+	}
+}
+
+func PrintTimer(resp http.ResponseWriter, req *http.Request) {
+	wl, status, err := wlService.GetTimer()
+	resp.WriteHeader(status)
+	if err != nil {
+		helpers.LogError(fmt.Sprintf("failed to find timer. %s", err.Error()), "print timer")
+		return
+	} else if wl == nil {
+		return
+	}
+	err = json.NewEncoder(resp).Encode(wl)
+	if err != nil {
+		helpers.LogError("failed to encode work", "print timer")
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+const defaultImportTagSeparator = ","
+
+func (s *service) ImportCSVFrom(path string, mapping *model.ImportMapping, strategy string, dryRun bool) (*model.ImportSummary, int, error) {
+	if !ValidImportStrategy(strategy) {
+		return nil, http.StatusBadRequest, errors.New(e.ImportStrategy)
+	}
+	if err := validateImportMapping(mapping); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	// #nosec G304 -- The user chooses which file to import
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s. %s", e.ImportRead, err.Error())
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s. %s", e.ImportCSV, err.Error())
+	} else if len(rows) == 0 {
+		return nil, http.StatusBadRequest, errors.New(e.ImportCSV)
+	}
+
+	columns, err := importColumns(rows[0], mapping)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	summary := &model.ImportSummary{DryRun: dryRun}
+	worklogs := []importRecord{}
+	for index, row := range rows[1:] {
+		if len(row) < len(rows[0]) {
+			summary.AddError(index+1, nil, errors.New(e.ImportRecordColumns))
+			continue
+		}
+		wl, err := parseImportRow(mapping, columns, row)
+		if err != nil {
+			summary.AddError(index+1, nil, err)
+			continue
+		}
+		if err := validateImport(wl); err != nil {
+			summary.AddError(index+1, wl, err)
+			continue
+		}
+		worklogs = append(worklogs, importRecord{record: index + 1, wl: wl})
+	}
+
+	return s.importWorklogs(worklogs, strategy, summary)
+}
+
+func validateImportMapping(mapping *model.ImportMapping) error {
+	if mapping == nil || len(mapping.Title) == 0 ||
+		mapping.Date == "" || mapping.Duration == "" {
+		return errors.New(e.ImportMapping)
+	}
+	switch mapping.DurationUnit {
+	case "", model.ImportDurationMinutes, model.ImportDurationHours, model.ImportDurationClock:
+		return nil
+	}
+	return errors.New(e.ImportDurationUnit)
+}
+
+// importColumns finds the position of each mapped column within the
+// header. Columns are matched ignoring their case.
+func importColumns(header []string, mapping *model.ImportMapping) (map[string]int, error) {
+	columns := make(map[string]int)
+	for index, column := range header {
+		column = strings.TrimPrefix(column, "\ufeff")
+		columns[strings.ToLower(strings.TrimSpace(column))] = index
+	}
+
+	mapped := append([]string{
+		mapping.Description,
+		mapping.Project,
+		mapping.Tags,
+		mapping.Date,
+		mapping.Time,
+		mapping.Duration,
+	}, mapping.Title...)
+	for _, column := range append(mapped, mapping.Author...) {
+		if _, ok := columns[strings.ToLower(column)]; column != "" && !ok {
+			return nil, fmt.Errorf("%s '%s'", e.ImportCSVColumn, column)
+		}
+	}
+	return columns, nil
+}
+
+// parseImportRow generates work from the mapped columns of the row.
+// The ID is generated from the row, so importing it again is treated
+// as the same work.
+func parseImportRow(mapping *model.ImportMapping, columns map[string]int, row []string) (*model.Work, error) {
+	value := func(column string) string {
+		if column == "" {
+			return ""
+		}
+		return strings.TrimSpace(row[columns[strings.ToLower(column)]])
+	}
+
+	rawWhen := value(mapping.Date)
+	if mapping.Time != "" {
+		rawWhen = strings.TrimSpace(rawWhen + " " + value(mapping.Time))
+	}
+	when, err := helpers.GetStringAsDateTime(rawWhen)
+	if err != nil {
+		return nil, fmt.Errorf("%s '%s'. %s", e.ImportRecordDate, rawWhen, err.Error())
+	}
+
+	rawDuration := value(mapping.Duration)
+	duration, err := parseImportDuration(rawDuration, mapping.DurationUnit)
+	if err != nil {
+		return nil, fmt.Errorf("%s '%s'. %s", e.ImportRecordDuration, rawDuration, err.Error())
+	}
+
+	var title string
+	for _, column := range mapping.Title {
+		if title = value(column); title != "" {
+			break
+		}
+	}
+
+	separator := mapping.TagSeparator
+	if separator == "" {
+		separator = defaultImportTagSeparator
+	}
+	tags := []string{}
+	for _, tag := range append(strings.Split(value(mapping.Tags), separator), value(mapping.Project)) {
+		if strings.TrimSpace(tag) != "" {
+			tags = append(tags, strings.TrimSpace(tag))
+		}
+	}
+
+	authors := []string{}
+	for _, column := range mapping.Author {
+		if author := value(column); author != "" {
+			authors = append(authors, author)
+		}
+	}
+
+	wl := model.NewWork(title, value(mapping.Description), strings.Join(authors, " "), duration, tags, when)
+	wl.ID = importID(strings.Join(row, "\x1f"))
+	return wl, nil
+}
+
+// parseImportDuration converts a duration in the unit into a number
+// of minutes
+func parseImportDuration(rawDuration, unit string) (int, error) {
+	switch unit {
+	case model.ImportDurationHours:
+		hours, err := strconv.ParseFloat(rawDuration, 64)
+		if err != nil {
+			return 0, err
+		} else if hours < 0 {
+			return 0, errors.New("must not be negative")
+		}
+		return int(math.Round(hours * 60)), nil
+	case model.ImportDurationClock:
+		parts := strings.Split(rawDuration, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return 0, errors.New("expected a format such as 01:30:00")
+		}
+		var seconds float64
+		for _, part := range parts {
+			number, err := strconv.Atoi(part)
+			if err != nil {
+				return 0, err
+			} else if number < 0 {
+				return 0, errors.New("must not be negative")
+			}
+			seconds = seconds*60 + float64(number)
+		}
+		if len(parts) == 2 {
+			seconds = seconds * 60
+		}
+		return int(math.Round(seconds / 60)), nil
+	}
+	return helpers.ParseDuration(rawDuration)
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const togglFile = "\ufeff" + `User,Email,Client,Project,Task,Description,Billable,Start date,Start time,End date,End time,Duration,Tags,Amount ()
+Alice,alice@example.com,,Worklog,,Write docs,No,2026-10-12,09:00:00,2026-10-12,10:30:00,01:30:00,"docs, writing",
+Alice,alice@example.com,,,,Review,No,yesterday,09:00:00,2026-10-12,10:30:00,01:30:00,,
+Alice,alice@example.com,,Worklog,,Plan,No,2026-10-13,09:00:00,2026-10-13,09:10:00,ten minutes,,
+`
+
+const clockifyFile = `Project,Client,Description,Task,User,Group,Email,Tags,Billable,Start Date,Start Time,End Date,End Time,Duration (h),Duration (decimal)
+Worklog,,Fix bug,,Bob,,bob@example.com,bug,Yes,10/12/2026,01:00:00 PM,10/12/2026,01:45:00 PM,00:45:00,0.75
+`
+
+const harvestFile = `Date,Client,Project,Project Code,Task,Notes,Hours,Hours Rounded,Billable?,Invoiced?,Approved?,First Name,Last Name
+2026-10-12,,Worklog,,Development,,2.5,2.5,Yes,No,No,Carol,Smith
+2026-10-12,,Worklog,,Development
+`
+
+func writeCSVImportFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "import.csv")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestImportCSVFrom(t *testing.T) {
+	var tests = []struct {
+		name      string
+		format    string
+		content   string
+		expSaved  []*model.Work
+		expErrors []model.ImportError
+	}{
+		{
+			name:    "Toggl",
+			format:  model.ImportFormatToggl,
+			content: togglFile,
+			expSaved: []*model.Work{{
+				Title:    "Write docs",
+				Author:   "Alice",
+				Duration: 90,
+				Tags:     []string{"Worklog", "docs", "writing"},
+				When:     time.Date(2026, time.October, 12, 9, 0, 0, 0, time.Local),
+			}},
+			expErrors: []model.ImportError{
+				{Record: 2, Error: e.ImportRecordDate},
+				{Record: 3, Error: e.ImportRecordDuration},
+			},
+		}, {
+			name:    "Clockify",
+			format:  model.ImportFormatClockify,
+			content: clockifyFile,
+			expSaved: []*model.Work{{
+				Title:    "Fix bug",
+				Author:   "Bob",
+				Duration: 45,
+				Tags:     []string{"Worklog", "bug"},
+				When:     time.Date(2026, time.October, 12, 13, 0, 0, 0, time.Local),
+			}},
+		}, {
+			name:    "Harvest",
+			format:  model.ImportFormatHarvest,
+			content: harvestFile,
+			expSaved: []*model.Work{{
+				Title:    "Development",
+				Author:   "Carol Smith",
+				Duration: 150,
+				Tags:     []string{"Worklog"},
+				When:     time.Date(2026, time.October, 12, 0, 0, 0, 0, time.Local),
+			}},
+			expErrors: []model.ImportError{
+				{Record: 2, Error: e.ImportRecordColumns},
+			},
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return([]*model.Work{}, nil)
+		mockRepo.On("Save", mock.Anything).Return(nil)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			summary, code, err := svc.ImportCSVFrom(writeCSVImportFile(t, testItem.content),
+				model.NewImportMapping(testItem.format), ImportSkip, false)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, code)
+			assert.Equal(t, len(testItem.expSaved), summary.Imported)
+			assert.Len(t, summary.Errors, len(testItem.expErrors))
+			for i, expErr := range testItem.expErrors {
+				assert.Equal(t, expErr.Record, summary.Errors[i].Record)
+				assert.Contains(t, summary.Errors[i].Error, expErr.Error)
+			}
+
+			saved := []*model.Work{}
+			for _, call := range mockRepo.Calls {
+				if call.Method == "Save" {
+					saved = append(saved, call.Arguments.Get(0).(*model.Work))
+				}
+			}
+			assert.Len(t, saved, len(testItem.expSaved))
+			for i, exp := range testItem.expSaved {
+				assert.Len(t, saved[i].ID, importIDLength)
+				assert.Equal(t, 1, saved[i].Revision)
+				assert.Equal(t, exp.Title, saved[i].Title)
+				assert.Equal(t, exp.Author, saved[i].Author)
+				assert.Equal(t, exp.Duration, saved[i].Duration)
+				assert.Equal(t, exp.Tags, saved[i].Tags)
+				assert.True(t, exp.When.Equal(saved[i].When))
+			}
+		})
+	}
+}
+
+func TestImportCSVFromSameRowSameID(t *testing.T) {
+	path := writeCSVImportFile(t, clockifyFile)
+	mapping := model.NewImportMapping(model.ImportFormatClockify)
+
+	var ids []string
+	for i := 0; i < 2; i++ {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return([]*model.Work{}, nil)
+		mockRepo.On("Save", mock.Anything).Return(nil)
+		svc := NewWorklogService(mockRepo)
+
+		_, _, err := svc.ImportCSVFrom(path, mapping, ImportSkip, false)
+		assert.Nil(t, err)
+		ids = append(ids, mockRepo.Calls[1].Arguments.Get(0).(*model.Work).ID)
+	}
+	assert.Equal(t, ids[0], ids[1])
+}
+
+func TestImportCSVFromErrors(t *testing.T) {
+	var tests = []struct {
+		name    string
+		content string
+		mapping *model.ImportMapping
+		expErr  string
+	}{
+		{
+			name:    "No mapping",
+			content: harvestFile,
+			expErr:  e.ImportMapping,
+		}, {
+			name:    "Mapping without a date",
+			content: harvestFile,
+			mapping: &model.ImportMapping{Title: []string{"Notes"}, Duration: "Hours"},
+			expErr:  e.ImportMapping,
+		}, {
+			name:    "Unknown duration unit",
+			content: harvestFile,
+			mapping: &model.ImportMapping{Title: []string{"Notes"}, Date: "Date", Duration: "Hours", DurationUnit: "days"},
+			expErr:  e.ImportDurationUnit,
+		}, {
+			name:    "Missing column",
+			content: harvestFile,
+			mapping: model.NewImportMapping(model.ImportFormatToggl),
+			expErr:  e.ImportCSVColumn,
+		}, {
+			name:    "Empty file",
+			content: "",
+			mapping: model.NewImportMapping(model.ImportFormatHarvest),
+			expErr:  e.ImportCSV,
+		},
+	}
+
+	for _, testItem := range tests {
+		svc := NewWorklogService(new(repository.MockRepo))
+
+		t.Run(testItem.name, func(t *testing.T) {
+			_, code, err := svc.ImportCSVFrom(writeCSVImportFile(t, testItem.content),
+				testItem.mapping, ImportSkip, false)
+
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.ErrorContains(t, err, testItem.expErr)
+		})
+	}
+}
+
+func TestParseImportDuration(t *testing.T) {
+	var tests = []struct {
+		name   string
+		raw    string
+		unit   string
+		exp    int
+		expErr bool
+	}{
+		{name: "Minutes", raw: "90", exp: 90},
+		{name: "Minutes with units", raw: "1h30m", unit: model.ImportDurationMinutes, exp: 90},
+		{name: "Hours", raw: "1.25", unit: model.ImportDurationHours, exp: 75},
+		{name: "Negative hours", raw: "-1", unit: model.ImportDurationHours, expErr: true},
+		{name: "Clock", raw: "01:30:00", unit: model.ImportDurationClock, exp: 90},
+		{name: "Clock rounds seconds", raw: "00:10:31", unit: model.ImportDurationClock, exp: 11},
+		{name: "Clock without seconds", raw: "2:05", unit: model.ImportDurationClock, exp: 125},
+		{name: "Clock without minutes", raw: "90", unit: model.ImportDurationClock, expErr: true},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			actual, err := parseImportDuration(testItem.raw, testItem.unit)
+
+			assert.Equal(t, testItem.expErr, err != nil)
+			assert.Equal(t, testItem.exp, actual)
+		})
+	}
+}
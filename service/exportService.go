@@ -0,0 +1,99 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+// Formats work can be exported in
+const (
+	ExportJSON   = "json"
+	ExportYAML   = "yaml"
+	ExportCSV    = "csv"
+	ExportNDJSON = "ndjson"
+	ExportICS    = "ics"
+)
+
+// ValidExportFormat whether the format is one of the export formats
+func ValidExportFormat(format string) bool {
+	return format == ExportJSON ||
+		format == ExportYAML ||
+		format == ExportCSV ||
+		format == ExportNDJSON ||
+		format == ExportICS
+}
+
+// ExportTo writes the work matching the dates and filter to the path.
+// Dates which aren't provided don't limit the work exported.
+func (*service) ExportTo(path, format string, start, end time.Time, filter *model.Work, latestOnly bool) (int, error) {
+	if !ValidExportFormat(format) {
+		return http.StatusBadRequest, errors.New(e.ExportFormat)
+	}
+
+	matches, err := model.FilterMatcher(filter)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	var all model.WorkList
+	all, err = repo.GetAll()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	// Calendars expect a single event for each UID
+	if latestOnly || format == ExportICS {
+		all = all.RemoveOldRevisions()
+	}
+
+	worklogs := []*model.Work{}
+	for _, wl := range all {
+		if (start.IsZero() || !wl.When.Before(start)) &&
+			(end.IsZero() || wl.When.Before(end)) &&
+			matches(wl) {
+			worklogs = append(worklogs, wl)
+		}
+	}
+	sort.SliceStable(worklogs, func(i, j int) bool {
+		if !worklogs[i].When.Equal(worklogs[j].When) {
+			return worklogs[i].When.Before(worklogs[j].When)
+		}
+		if worklogs[i].ID != worklogs[j].ID {
+			return worklogs[i].ID < worklogs[j].ID
+		}
+		return worklogs[i].Revision < worklogs[j].Revision
+	})
+
+	var b bytes.Buffer
+	switch format {
+	case ExportYAML:
+		err = model.WriteAllWorkToYAML(&b, worklogs)
+	case ExportCSV:
+		err = model.WriteAllWorkToCSV(&b, worklogs)
+	case ExportNDJSON:
+		err = model.WriteAllWorkToNDJSON(&b, worklogs)
+	case ExportICS:
+		err = model.WriteAllWorkToICS(&b, worklogs)
+	default:
+		err = model.WriteAllWorkToJSON(&b, worklogs)
+	}
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error encoding worklogs. %s", err.Error())
+	}
+	// #nosec G306 -- Not concerned that others on machine can access the exported values
+	// if the user wants to update permissions later, they can
+	err = os.WriteFile(path, b.Bytes(), 0644)
+	if err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("error saving file. %s", err.Error())
+	}
+
+	helpers.LogDebug(fmt.Sprintf("Saved export file of %d worklogs", len(worklogs)), "save export successful - "+format)
+	return http.StatusOK, nil
+}
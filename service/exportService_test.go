@@ -0,0 +1,178 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func exportRevisions() []*model.Work {
+	october := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	november := time.Date(2026, time.November, 2, 9, 0, 0, 0, time.UTC)
+	return []*model.Work{
+		{ID: "aaa", Revision: 1, Title: "Invoice", Tags: []string{"client-a"}, When: october},
+		{ID: "aaa", Revision: 2, Title: "Invoice edited", Tags: []string{"client-a"}, When: october},
+		{ID: "bbb", Revision: 1, Title: "Call", Tags: []string{"client-b"}, When: october.Add(time.Hour)},
+		{ID: "ccc", Revision: 1, Title: "Later", Tags: []string{"client-a"}, When: november},
+		{ID: "ddd", Revision: 1, Title: "Removed", Tags: []string{"client-a"}, When: october},
+		{ID: "ddd", Revision: 2, Title: "Removed", Tags: []string{"client-a"}, When: october, Deleted: true},
+	}
+}
+
+func TestExportTo(t *testing.T) {
+	var tests = []struct {
+		name       string
+		start      time.Time
+		end        time.Time
+		filter     *model.Work
+		latestOnly bool
+		exp        []string
+	}{
+		{
+			name: "Every revision",
+			exp:  []string{"aaa_1", "aaa_2", "ddd_1", "ddd_2", "bbb_1", "ccc_1"},
+		}, {
+			name:       "Latest revisions",
+			latestOnly: true,
+			exp:        []string{"aaa_2", "bbb_1", "ccc_1"},
+		}, {
+			name:       "Single clients month",
+			start:      time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+			end:        time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+			filter:     &model.Work{Tags: []string{"client-a"}},
+			latestOnly: true,
+			exp:        []string{"aaa_2"},
+		}, {
+			name:   "Filtered revisions",
+			filter: &model.Work{Title: "edited"},
+			exp:    []string{"aaa_2"},
+		}, {
+			name:   "Filtered by expression",
+			filter: &model.Work{Title: "^removed$|edited$"},
+			exp:    []string{"aaa_2", "ddd_1", "ddd_2"},
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return(exportRevisions(), nil)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "export.json")
+
+			code, err := svc.ExportTo(path, ExportJSON, testItem.start, testItem.end, testItem.filter, testItem.latestOnly)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, code)
+			b, _ := os.ReadFile(path)
+			var exported []*model.Work
+			assert.Nil(t, json.Unmarshal(b, &exported))
+			actual := []string{}
+			for _, wl := range exported {
+				actual = append(actual, fmt.Sprintf("%s_%d", wl.ID, wl.Revision))
+			}
+			assert.Equal(t, testItem.exp, actual)
+		})
+	}
+}
+
+func TestExportToFormats(t *testing.T) {
+	var tests = []struct {
+		format   string
+		expStart string
+		expLines int
+	}{
+		{format: ExportJSON, expStart: "[{", expLines: 1},
+		{format: ExportYAML, expStart: "- id: aaa", expLines: 27},
+		{format: ExportCSV, expStart: "id,revision,title", expLines: 4},
+		{format: ExportNDJSON, expStart: `{"id":"aaa"`, expLines: 3},
+		{format: ExportICS, expStart: "BEGIN:VCALENDAR", expLines: 31},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return(exportRevisions(), nil)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.format, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "export")
+
+			code, err := svc.ExportTo(path, testItem.format, time.Time{}, time.Time{}, nil, true)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, code)
+			b, _ := os.ReadFile(path)
+			assert.True(t, strings.HasPrefix(string(b), testItem.expStart), string(b))
+			assert.Len(t, strings.Split(strings.TrimSpace(string(b)), "\n"), testItem.expLines)
+		})
+	}
+}
+
+func TestExportToICSLatestOnly(t *testing.T) {
+	mockRepo := new(repository.MockRepo)
+	mockRepo.On("GetAll").Return(exportRevisions(), nil)
+	svc := NewWorklogService(mockRepo)
+	path := filepath.Join(t.TempDir(), "export.ics")
+
+	code, err := svc.ExportTo(path, ExportICS, time.Time{}, time.Time{}, nil, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	b, _ := os.ReadFile(path)
+	assert.Equal(t, 3, strings.Count(string(b), "BEGIN:VEVENT"))
+	assert.Equal(t, 1, strings.Count(string(b), "UID:aaa@worklog"))
+	assert.NotContains(t, string(b), "UID:ddd@worklog")
+}
+
+func TestExportToErrors(t *testing.T) {
+	randErr := errors.New(helpers.RandAlphabeticString(strLength))
+
+	var tests = []struct {
+		name    string
+		format  string
+		getErr  error
+		expCode int
+		expErr  error
+	}{
+		{
+			name:    "Unknown format",
+			format:  "xml",
+			expCode: http.StatusBadRequest,
+			expErr:  errors.New(e.ExportFormat),
+		}, {
+			name:    "Error getting worklogs",
+			format:  ExportJSON,
+			getErr:  randErr,
+			expCode: http.StatusInternalServerError,
+			expErr:  randErr,
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return([]*model.Work{}, testItem.getErr)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			code, err := svc.ExportTo(filepath.Join(t.TempDir(), "export"), testItem.format,
+				time.Time{}, time.Time{}, nil, false)
+
+			assert.Equal(t, testItem.expCode, code)
+			assert.Equal(t, testItem.expErr, err)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/viper"
+)
+
+// GitSourcePrefix marks work generated from a git commit, and is
+// followed by the commit's hash
+const GitSourcePrefix = "git:"
+
+// Separators git is asked to output between the fields and commits of its log
+const (
+	gitFieldSeparator  = "\x1f"
+	gitCommitSeparator = "\x1e"
+)
+
+func (*service) ProposeGitWorklogs(path string, since time.Time, author string) ([]*model.Work, int, error) {
+	topLevel, err := runGit(path, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s. %s", e.ImportGitRepo, err.Error())
+	}
+	tags := []string{filepath.Base(topLevel)}
+	// A detached head has no branch to tag the work with
+	if branch, err := runGit(path, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		tags = append(tags, branch)
+	}
+
+	args := []string{"log", "--no-merges", "--format=%H%x1f%an%x1f%at%x1f%s%x1f%b%x1e"}
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	if author != "" {
+		args = append(args, "--author="+author)
+	}
+	log, err := runGit(path, args...)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s. %s", e.ImportGitLog, err.Error())
+	}
+
+	existing, err := repo.GetAll()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	imported := make(map[string]bool)
+	for _, wl := range existing {
+		if wl.Source != "" {
+			imported[wl.Source] = true
+		}
+	}
+
+	proposed := []*model.Work{}
+	for _, commit := range strings.Split(log, gitCommitSeparator) {
+		fields := strings.SplitN(strings.TrimSpace(commit), gitFieldSeparator, 5)
+		if len(fields) != 5 {
+			continue
+		}
+		source := GitSourcePrefix + fields[0]
+		if imported[source] {
+			continue
+		}
+		epoch, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("%s. %s", e.ImportGitLog, err.Error())
+		}
+
+		wl := model.NewWork(
+			strings.TrimSpace(fields[3]),
+			strings.TrimSpace(fields[4]),
+			fields[1],
+			viper.GetInt("default.duration"),
+			append([]string{}, tags...),
+			time.Unix(epoch, 0))
+		wl.Source = source
+		// The log is newest first, while work is proposed oldest first
+		proposed = append([]*model.Work{wl}, proposed...)
+	}
+	return proposed, http.StatusOK, nil
+}
+
+// runGit runs git against the repository at the path, returning
+// its trimmed output
+func runGit(path string, args ...string) (string, error) {
+	// #nosec G204 -- Arguments are passed to git directly, not through a shell
+	out, err := exec.Command("git", append([]string{"-C", path}, args...)...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) != 0 {
+			return "", errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
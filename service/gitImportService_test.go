@@ -0,0 +1,118 @@
+package service
+
+import (
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gitCommit(t *testing.T, dir, author, when string, message ...string) string {
+	args := []string{"-C", dir, "-c", "user.name=" + author, "-c", "user.email=" + author + "@example.com",
+		"commit", "--allow-empty", "--date", when}
+	for _, m := range message {
+		args = append(args, "-m", m)
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Env = append(cmd.Environ(), "GIT_COMMITTER_DATE="+when)
+	assert.Nil(t, cmd.Run())
+
+	hash, err := runGit(dir, "rev-parse", "HEAD")
+	assert.Nil(t, err)
+	return hash
+}
+
+func newGitRepo(t *testing.T) (string, []string) {
+	dir := filepath.Join(t.TempDir(), "project")
+	assert.Nil(t, exec.Command("git", "init", "-q", "-b", "main", dir).Run())
+	return dir, []string{
+		gitCommit(t, dir, "alice", "2026-10-01T09:00:00Z", "Add parser", "Parses the config\nfile"),
+		gitCommit(t, dir, "bob", "2026-10-02T09:00:00Z", "Fix typo"),
+		gitCommit(t, dir, "alice", "2026-10-03T09:00:00Z", "Add tests"),
+	}
+}
+
+func TestProposeGitWorklogs(t *testing.T) {
+	dir, hashes := newGitRepo(t)
+
+	var tests = []struct {
+		name     string
+		since    time.Time
+		author   string
+		existing []*model.Work
+		expTitle []string
+		expHash  []string
+	}{
+		{
+			name:     "All commits oldest first",
+			expTitle: []string{"Add parser", "Fix typo", "Add tests"},
+			expHash:  hashes,
+		}, {
+			name:     "Since date",
+			since:    time.Date(2026, time.October, 2, 0, 0, 0, 0, time.UTC),
+			expTitle: []string{"Fix typo", "Add tests"},
+			expHash:  hashes[1:],
+		}, {
+			name:     "By author",
+			author:   "alice",
+			expTitle: []string{"Add parser", "Add tests"},
+			expHash:  []string{hashes[0], hashes[2]},
+		}, {
+			name:     "Already imported",
+			existing: []*model.Work{{ID: "abc", Revision: 1, Source: GitSourcePrefix + hashes[1]}},
+			expTitle: []string{"Add parser", "Add tests"},
+			expHash:  []string{hashes[0], hashes[2]},
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return(testItem.existing, nil)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			proposed, code, err := svc.ProposeGitWorklogs(dir, testItem.since, testItem.author)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, code)
+			titles := []string{}
+			for i, wl := range proposed {
+				titles = append(titles, wl.Title)
+				assert.Equal(t, GitSourcePrefix+testItem.expHash[i], wl.Source)
+				assert.Equal(t, []string{"main", "project"}, wl.Tags)
+			}
+			assert.Equal(t, testItem.expTitle, titles)
+		})
+	}
+}
+
+func TestProposeGitWorklogsFields(t *testing.T) {
+	dir, _ := newGitRepo(t)
+	mockRepo := new(repository.MockRepo)
+	mockRepo.On("GetAll").Return([]*model.Work{}, nil)
+	svc := NewWorklogService(mockRepo)
+
+	proposed, _, err := svc.ProposeGitWorklogs(dir, time.Time{}, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Add parser", proposed[0].Title)
+	assert.Equal(t, "Parses the config\nfile", proposed[0].Description)
+	assert.Equal(t, "alice", proposed[0].Author)
+	assert.True(t, time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC).Equal(proposed[0].When))
+}
+
+func TestProposeGitWorklogsNotARepo(t *testing.T) {
+	svc := NewWorklogService(new(repository.MockRepo))
+
+	_, code, err := svc.ProposeGitWorklogs(t.TempDir(), time.Time{}, "")
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.ErrorContains(t, err, e.ImportGitRepo)
+}
@@ -0,0 +1,809 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+
+	"github.com/spf13/viper"
+)
+
+// ICSSourcePrefix marks work imported from a calendar event, and is
+// followed by the event's UID
+const ICSSourcePrefix = "ics:"
+
+// Formats of dates and times within a calendar
+const (
+	icsDateFormat     = "20060102"
+	icsDateTimeFormat = "20060102T150405"
+	icsUTCFormat      = icsDateTimeFormat + "Z"
+)
+
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+var icsUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\n`, "\n",
+	`\N`, "\n",
+	`\,`, ",",
+	`\;`, ";",
+)
+
+// icsProperty a single content line of a calendar
+type icsProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icsEvent the properties of a VEVENT by their name, along with
+// its position within the calendar
+type icsEvent struct {
+	record int
+	props  map[string][]icsProperty
+}
+
+// icsInstance a single occurrence of an event
+type icsInstance struct {
+	event  icsEvent
+	start  time.Time
+	source string
+}
+
+// icsRule the parts of a recurrence rule which are supported
+type icsRule struct {
+	freq       string
+	interval   int
+	count      int
+	until      time.Time
+	byDay      []icsWeekday
+	byMonthDay []int
+	byMonth    []time.Month
+}
+
+// icsWeekday a day of the week, with the ordinal of which of them
+// within the month is meant. An ordinal of 0 means every one.
+type icsWeekday struct {
+	ordinal int
+	day     time.Weekday
+}
+
+func (s *service) ImportICSFrom(path string, options *model.ICSImportOptions, strategy string, dryRun bool) (*model.ImportSummary, int, error) {
+	if !ValidImportStrategy(strategy) {
+		return nil, http.StatusBadRequest, errors.New(e.ImportStrategy)
+	}
+	if options == nil {
+		options = &model.ICSImportOptions{}
+	}
+
+	// #nosec G304 -- The user chooses which file to import
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s. %s", e.ImportRead, err.Error())
+	}
+	events, err := parseICSEvents(string(b))
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	// Work can't have been done in the future, so events are only
+	// imported up until now by default
+	end := options.End
+	if end.IsZero() {
+		end = time.Now()
+	}
+	attendee := options.Attendee
+	if attendee == "" {
+		attendee = viper.GetString("default.author")
+	}
+
+	summary := &model.ImportSummary{DryRun: dryRun}
+	instances, errs := icsInstances(events, end)
+	for record, err := range errs {
+		summary.AddError(record, nil, err)
+	}
+
+	worklogs := []importRecord{}
+	for _, instance := range instances {
+		if instance.start.Before(options.Start) || !instance.start.Before(end) {
+			continue
+		}
+		ev := instance.event
+		_, allDay, _ := parseICSTime(*ev.first("DTSTART"))
+		if strings.EqualFold(ev.text("STATUS"), "CANCELLED") ||
+			(options.SkipAllDay && allDay) ||
+			(options.SkipDeclined && ev.declinedBy(attendee)) {
+			summary.Skipped++
+			continue
+		}
+
+		length, err := ev.length()
+		if err != nil {
+			summary.AddError(ev.record, nil, fmt.Errorf("%s. %s", e.ImportRecordDuration, err.Error()))
+			continue
+		}
+		wl := model.NewWork(
+			ev.text("SUMMARY"),
+			ev.text("DESCRIPTION"),
+			helpers.Sanitize(viper.GetString("default.author")),
+			int(math.Round(length.Minutes())),
+			ev.categories(),
+			instance.start)
+		wl.ID = importID(instance.source)
+		wl.Source = instance.source
+		if err := validateImport(wl); err != nil {
+			summary.AddError(ev.record, wl, err)
+			continue
+		}
+		worklogs = append(worklogs, importRecord{record: ev.record, wl: wl})
+	}
+
+	return s.importWorklogs(worklogs, strategy, summary)
+}
+
+// icsInstances expands each event into its occurrences until the end.
+// Occurrences of a recurring event which have been moved or changed
+// replace the original occurrence. Events which can't be expanded are
+// returned by their record.
+func icsInstances(events []icsEvent, end time.Time) ([]icsInstance, map[int]error) {
+	errs := make(map[int]error)
+	overrides := make(map[string]map[int64]icsEvent)
+	masters := []icsEvent{}
+	for _, ev := range events {
+		uid := ev.text("UID")
+		if uid == "" {
+			errs[ev.record] = errors.New(e.ImportICSUID)
+			continue
+		} else if ev.first("DTSTART") == nil {
+			errs[ev.record] = errors.New(e.ImportRecordWhen)
+			continue
+		} else if _, _, err := parseICSTime(*ev.first("DTSTART")); err != nil {
+			errs[ev.record] = fmt.Errorf("%s. %s", e.ImportRecordDate, err.Error())
+			continue
+		}
+
+		recurrenceID := ev.first("RECURRENCE-ID")
+		if recurrenceID == nil {
+			masters = append(masters, ev)
+			continue
+		}
+		original, _, err := parseICSTime(*recurrenceID)
+		if err != nil {
+			errs[ev.record] = fmt.Errorf("%s. %s", e.ImportRecordDate, err.Error())
+			continue
+		}
+		if overrides[uid] == nil {
+			overrides[uid] = make(map[int64]icsEvent)
+		}
+		overrides[uid][original.Unix()] = ev
+	}
+
+	instances := []icsInstance{}
+	for _, ev := range masters {
+		uid := ev.text("UID")
+		start, _, _ := parseICSTime(*ev.first("DTSTART"))
+		if ev.first("RRULE") == nil && ev.first("RDATE") == nil {
+			instances = append(instances, icsInstance{event: ev, start: start, source: ICSSourcePrefix + uid})
+			continue
+		}
+
+		starts, err := ev.recurrences(start, end)
+		if err != nil {
+			errs[ev.record] = fmt.Errorf("%s. %s", e.ImportICSRecurrence, err.Error())
+			continue
+		}
+		for _, occurrence := range starts {
+			instance := icsInstance{
+				event:  ev,
+				start:  occurrence,
+				source: icsInstanceSource(uid, occurrence),
+			}
+			if override, ok := overrides[uid][occurrence.Unix()]; ok {
+				instance.event = override
+				instance.start, _, _ = parseICSTime(*override.first("DTSTART"))
+				delete(overrides[uid], occurrence.Unix())
+			}
+			instances = append(instances, instance)
+		}
+	}
+
+	// Changed occurrences whose original occurrence wasn't expanded,
+	// such as those moved into the range, are imported by themselves
+	for uid, byOriginal := range overrides {
+		for original, ev := range byOriginal {
+			start, _, _ := parseICSTime(*ev.first("DTSTART"))
+			instances = append(instances, icsInstance{
+				event:  ev,
+				start:  start,
+				source: icsInstanceSource(uid, time.Unix(original, 0)),
+			})
+		}
+	}
+	return instances, errs
+}
+
+// icsInstanceSource the source of an occurrence of a recurring event,
+// which is from when it originally occurred, so stays the same if the
+// occurrence is moved
+func icsInstanceSource(uid string, original time.Time) string {
+	return ICSSourcePrefix + uid + "/" + original.UTC().Format(icsUTCFormat)
+}
+
+// parseICSEvents reads the events of a calendar. Components within an
+// event, such as alarms, are ignored.
+func parseICSEvents(content string) ([]icsEvent, error) {
+	content = strings.TrimPrefix(content, "\ufeff")
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	// Lines starting with whitespace are a continuation of the previous line
+	content = strings.NewReplacer("\n ", "", "\n\t", "").Replace(content)
+
+	calendar := false
+	events := []icsEvent{}
+	var current *icsEvent
+	nested := 0
+	for _, line := range strings.Split(content, "\n") {
+		prop, ok := parseICSProperty(strings.TrimRight(line, "\r"))
+		if !ok {
+			continue
+		}
+		isBegin := prop.name == "BEGIN"
+		isEnd := prop.name == "END"
+		isEvent := strings.EqualFold(prop.value, "VEVENT")
+
+		switch {
+		case isBegin && strings.EqualFold(prop.value, "VCALENDAR"):
+			calendar = true
+		case isBegin && current == nil && isEvent:
+			current = &icsEvent{record: len(events) + 1, props: make(map[string][]icsProperty)}
+		case isBegin && current != nil:
+			nested++
+		case isEnd && current != nil && nested > 0:
+			nested--
+		case isEnd && current != nil && isEvent:
+			events = append(events, *current)
+			current = nil
+		case current != nil && nested == 0:
+			current.props[prop.name] = append(current.props[prop.name], prop)
+		}
+	}
+
+	if !calendar {
+		return nil, errors.New(e.ImportICS)
+	}
+	return events, nil
+}
+
+// parseICSProperty splits a content line into its name, parameters
+// and value
+func parseICSProperty(line string) (icsProperty, bool) {
+	parts := splitOutsideQuotes(line, ':')
+	if len(parts) < 2 {
+		return icsProperty{}, false
+	}
+	head := splitOutsideQuotes(parts[0], ';')
+	prop := icsProperty{
+		name:   strings.ToUpper(strings.TrimSpace(head[0])),
+		params: make(map[string]string),
+		// Only the first colon separates the value, which may contain more
+		value: line[len(parts[0])+1:],
+	}
+	for _, param := range head[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, prop.name != ""
+}
+
+// splitOutsideQuotes splits the value at each separator which isn't
+// within double quotes
+func splitOutsideQuotes(value string, separator rune) []string {
+	parts := []string{}
+	quoted := false
+	start := 0
+	for i, r := range value {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == separator && !quoted {
+			parts = append(parts, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// parseICSTime reads a date or date-time property, and whether it was
+// only a date. Times without a zone are in the local zone, as are zones
+// which aren't known.
+func parseICSTime(prop icsProperty) (time.Time, bool, error) {
+	times, allDay, err := parseICSTimes(prop)
+	if err != nil {
+		return time.Time{}, false, err
+	} else if len(times) != 1 {
+		return time.Time{}, false, fmt.Errorf("expected a single time '%s'", prop.value)
+	}
+	return times[0], allDay, nil
+}
+
+// parseICSTimes reads a property which is a list of dates or date-times
+func parseICSTimes(prop icsProperty) ([]time.Time, bool, error) {
+	loc := time.Local
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if tzLoc, err := time.LoadLocation(tzid); err == nil {
+			loc = tzLoc
+		}
+	}
+
+	times := []time.Time{}
+	allDay := false
+	for _, value := range strings.Split(strings.TrimSpace(prop.value), ",") {
+		var t time.Time
+		var err error
+		switch {
+		case strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len(icsDateFormat):
+			allDay = true
+			t, err = time.ParseInLocation(icsDateFormat, value, time.Local)
+		case strings.HasSuffix(value, "Z"):
+			t, err = time.Parse(icsUTCFormat, value)
+		default:
+			t, err = time.ParseInLocation(icsDateTimeFormat, value, loc)
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		times = append(times, t)
+	}
+	return times, allDay, nil
+}
+
+// parseICSDuration reads a duration such as P1DT2H30M
+func parseICSDuration(raw string) (time.Duration, error) {
+	value := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(raw)), "+")
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("expected a duration such as PT1H30M '%s'", raw)
+	}
+
+	var total time.Duration
+	units := map[string]time.Duration{
+		"W":  7 * 24 * time.Hour,
+		"D":  24 * time.Hour,
+		"TH": time.Hour,
+		"TM": time.Minute,
+		"TS": time.Second,
+	}
+	section := ""
+	number := ""
+	for _, r := range value[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			number += string(r)
+		case r == 'T' && section == "":
+			section = "T"
+		default:
+			unit, ok := units[section+string(r)]
+			amount, err := strconv.Atoi(number)
+			if !ok || err != nil {
+				return 0, fmt.Errorf("expected a duration such as PT1H30M '%s'", raw)
+			}
+			total += time.Duration(amount) * unit
+			number = ""
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("expected a duration such as PT1H30M '%s'", raw)
+	}
+	return total, nil
+}
+
+// first the first property of the event with the name
+func (ev icsEvent) first(name string) *icsProperty {
+	if props := ev.props[name]; len(props) != 0 {
+		return &props[0]
+	}
+	return nil
+}
+
+// text the unescaped value of the first property with the name
+func (ev icsEvent) text(name string) string {
+	if prop := ev.first(name); prop != nil {
+		return strings.TrimSpace(icsUnescaper.Replace(prop.value))
+	}
+	return ""
+}
+
+// categories every category of the event, which can be split over
+// multiple properties
+func (ev icsEvent) categories() []string {
+	categories := []string{}
+	for _, prop := range ev.props["CATEGORIES"] {
+		for _, category := range splitICSList(prop.value) {
+			if category = strings.TrimSpace(icsUnescaper.Replace(category)); category != "" {
+				categories = append(categories, category)
+			}
+		}
+	}
+	return categories
+}
+
+// splitICSList splits the value at each comma which hasn't been escaped
+func splitICSList(value string) []string {
+	parts := []string{}
+	var part strings.Builder
+	escaped := false
+	for _, r := range value {
+		switch {
+		case escaped:
+			part.WriteRune('\\')
+			part.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ',':
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteRune(r)
+		}
+	}
+	return append(parts, part.String())
+}
+
+// length of the event from its end or duration. Events with neither
+// last a day when they're all day, otherwise they take no time.
+func (ev icsEvent) length() (time.Duration, error) {
+	start, allDay, err := parseICSTime(*ev.first("DTSTART"))
+	if err != nil {
+		return 0, err
+	}
+	if prop := ev.first("DTEND"); prop != nil {
+		end, _, err := parseICSTime(*prop)
+		if err != nil {
+			return 0, err
+		} else if end.Before(start) {
+			return 0, errors.New("event ends before it starts")
+		}
+		return end.Sub(start), nil
+	} else if prop := ev.first("DURATION"); prop != nil {
+		return parseICSDuration(prop.value)
+	} else if allDay {
+		return 24 * time.Hour, nil
+	}
+	return 0, nil
+}
+
+// declinedBy whether the attendee, matched by their name or email
+// address, has declined the event
+func (ev icsEvent) declinedBy(attendee string) bool {
+	if attendee == "" {
+		return false
+	}
+	for _, prop := range ev.props["ATTENDEE"] {
+		address := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(prop.value)), "mailto:")
+		if (strings.EqualFold(prop.params["CN"], attendee) || strings.EqualFold(address, attendee)) &&
+			strings.EqualFold(prop.params["PARTSTAT"], "DECLINED") {
+			return true
+		}
+	}
+	return false
+}
+
+// recurrences the start of each occurrence of the event, from its
+// first occurrence until the end, excluding those which are excluded
+func (ev icsEvent) recurrences(start, end time.Time) ([]time.Time, error) {
+	starts := []time.Time{start}
+	if prop := ev.first("RRULE"); prop != nil {
+		rule, err := parseICSRule(prop.value)
+		if err != nil {
+			return nil, err
+		}
+		starts = rule.expand(start, end)
+	}
+	for _, prop := range ev.props["RDATE"] {
+		times, _, err := parseICSTimes(prop)
+		if err != nil {
+			return nil, err
+		}
+		starts = append(starts, times...)
+	}
+
+	excluded := make(map[int64]bool)
+	for _, prop := range ev.props["EXDATE"] {
+		times, _, err := parseICSTimes(prop)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range times {
+			excluded[t.Unix()] = true
+		}
+	}
+
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+	occurrences := []time.Time{}
+	for _, occurrence := range starts {
+		if excluded[occurrence.Unix()] ||
+			(len(occurrences) != 0 && occurrences[len(occurrences)-1].Equal(occurrence)) {
+			continue
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences, nil
+}
+
+// parseICSRule reads a recurrence rule, such as FREQ=WEEKLY;BYDAY=MO,WE
+func parseICSRule(raw string) (*icsRule, error) {
+	rule := &icsRule{interval: 1}
+	for _, part := range strings.Split(strings.TrimSpace(raw), ";") {
+		key, value, _ := strings.Cut(part, "=")
+		value = strings.ToUpper(strings.TrimSpace(value))
+		var err error
+
+		switch strings.ToUpper(strings.TrimSpace(key)) {
+		case "FREQ":
+			rule.freq = value
+		case "INTERVAL":
+			rule.interval, err = strconv.Atoi(value)
+			if err == nil && rule.interval < 1 {
+				err = errors.New("interval must be at least 1")
+			}
+		case "COUNT":
+			rule.count, err = strconv.Atoi(value)
+			if err == nil && rule.count < 1 {
+				err = errors.New("count must be at least 1")
+			}
+		case "UNTIL":
+			var allDay bool
+			rule.until, allDay, err = parseICSTime(icsProperty{value: value})
+			// Until is inclusive, so includes every occurrence on the date
+			if err == nil && allDay {
+				rule.until = rule.until.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			}
+		case "BYDAY":
+			rule.byDay, err = parseICSWeekdays(value)
+		case "BYMONTHDAY":
+			rule.byMonthDay, err = parseICSNumbers(value, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseICSNumbers(value, 12)
+			for _, month := range months {
+				if month < 0 {
+					err = fmt.Errorf("unsupported month '%d'", month)
+				}
+				rule.byMonth = append(rule.byMonth, time.Month(month))
+			}
+		case "WKST", "":
+			// Weeks are always considered to start on a Monday
+		default:
+			err = fmt.Errorf("unsupported rule part '%s'", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch rule.freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+		return rule, nil
+	}
+	return nil, fmt.Errorf("unsupported frequency '%s'", rule.freq)
+}
+
+// parseICSWeekdays reads a list of days, such as MO,-1FR
+func parseICSWeekdays(value string) ([]icsWeekday, error) {
+	weekdays := []icsWeekday{}
+	for _, raw := range strings.Split(value, ",") {
+		if len(raw) < 2 {
+			return nil, fmt.Errorf("unknown day '%s'", raw)
+		}
+		day, ok := icsWeekdays[raw[len(raw)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("unknown day '%s'", raw)
+		}
+		weekday := icsWeekday{day: day}
+		if ordinal := raw[:len(raw)-2]; ordinal != "" {
+			var err error
+			if weekday.ordinal, err = strconv.Atoi(ordinal); err != nil || weekday.ordinal == 0 {
+				return nil, fmt.Errorf("unknown day '%s'", raw)
+			}
+		}
+		weekdays = append(weekdays, weekday)
+	}
+	return weekdays, nil
+}
+
+// parseICSNumbers reads a list of numbers, which can count back from
+// the maximum when negative
+func parseICSNumbers(value string, maximum int) ([]int, error) {
+	numbers := []int{}
+	for _, raw := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, err
+		} else if number == 0 || number > maximum || number < -maximum {
+			return nil, fmt.Errorf("'%d' must be between 1 and %d", number, maximum)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+// expand the start of each occurrence of the rule, from the start until
+// the end, the rule's count of occurrences, or its until date
+func (rule *icsRule) expand(start, end time.Time) []time.Time {
+	starts := []time.Time{}
+	for period := 0; ; period++ {
+		periodStart, candidates := rule.period(start, period)
+		if !periodStart.Before(end) ||
+			(!rule.until.IsZero() && periodStart.After(rule.until)) {
+			return starts
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			return candidates[i].Before(candidates[j])
+		})
+		for _, candidate := range candidates {
+			if candidate.Before(start) ||
+				(len(starts) != 0 && !candidate.After(starts[len(starts)-1])) {
+				continue
+			} else if !candidate.Before(end) ||
+				(!rule.until.IsZero() && candidate.After(rule.until)) {
+				return starts
+			}
+			starts = append(starts, candidate)
+			if rule.count != 0 && len(starts) == rule.count {
+				return starts
+			}
+		}
+	}
+}
+
+// period the start of the nth period of the rule after the start,
+// along with each occurrence within it
+func (rule *icsRule) period(start time.Time, n int) (time.Time, []time.Time) {
+	offset := n * rule.interval
+	switch rule.freq {
+	case "DAILY":
+		day := icsOnDay(start, start.Year(), start.Month(), start.Day()+offset)
+		if !rule.inMonth(day) || !rule.onMonthDay(day) || !rule.onWeekday(day) {
+			return day, nil
+		}
+		return day, []time.Time{day}
+	case "WEEKLY":
+		// Weeks start on a Monday
+		fromMonday := (int(start.Weekday()) + 6) % 7
+		monday := icsOnDay(start, start.Year(), start.Month(), start.Day()-fromMonday+7*offset)
+		weekdays := rule.byDay
+		if len(weekdays) == 0 {
+			weekdays = []icsWeekday{{day: start.Weekday()}}
+		}
+		days := []time.Time{}
+		for _, weekday := range weekdays {
+			day := icsOnDay(start, monday.Year(), monday.Month(), monday.Day()+(int(weekday.day)+6)%7)
+			if rule.inMonth(day) {
+				days = append(days, day)
+			}
+		}
+		return monday, days
+	case "MONTHLY":
+		first := icsOnDay(start, start.Year(), start.Month()+time.Month(offset), 1)
+		return first, rule.monthDays(start, first)
+	default:
+		first := icsOnDay(start, start.Year()+offset, time.January, 1)
+		months := rule.byMonth
+		if len(months) == 0 {
+			months = []time.Month{start.Month()}
+		}
+		days := []time.Time{}
+		for _, month := range months {
+			days = append(days, rule.monthDays(start, icsOnDay(start, first.Year(), month, 1))...)
+		}
+		return first, days
+	}
+}
+
+// monthDays the occurrences of the rule within the month starting on
+// first. Without days to occur on, it occurs on the same day as the start.
+func (rule *icsRule) monthDays(start, first time.Time) []time.Time {
+	if !rule.inMonth(first) {
+		return nil
+	}
+	last := first.AddDate(0, 1, -1).Day()
+	days := []time.Time{}
+
+	switch {
+	case len(rule.byMonthDay) != 0:
+		for _, monthDay := range rule.byMonthDay {
+			if monthDay < 0 {
+				monthDay += last + 1
+			}
+			day := icsOnDay(start, first.Year(), first.Month(), monthDay)
+			if monthDay >= 1 && monthDay <= last && rule.onWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case len(rule.byDay) != 0:
+		for _, weekday := range rule.byDay {
+			matching := []time.Time{}
+			for monthDay := 1; monthDay <= last; monthDay++ {
+				day := icsOnDay(start, first.Year(), first.Month(), monthDay)
+				if day.Weekday() == weekday.day {
+					matching = append(matching, day)
+				}
+			}
+			switch {
+			case weekday.ordinal == 0:
+				days = append(days, matching...)
+			case weekday.ordinal > 0 && weekday.ordinal <= len(matching):
+				days = append(days, matching[weekday.ordinal-1])
+			case weekday.ordinal < 0 && -weekday.ordinal <= len(matching):
+				days = append(days, matching[len(matching)+weekday.ordinal])
+			}
+		}
+	case start.Day() <= last:
+		days = append(days, icsOnDay(start, first.Year(), first.Month(), start.Day()))
+	}
+	return days
+}
+
+// inMonth whether the day is within the months of the rule
+func (rule *icsRule) inMonth(day time.Time) bool {
+	if len(rule.byMonth) == 0 {
+		return true
+	}
+	for _, month := range rule.byMonth {
+		if day.Month() == month {
+			return true
+		}
+	}
+	return false
+}
+
+// onMonthDay whether the day is one of the days of the month of the rule
+func (rule *icsRule) onMonthDay(day time.Time) bool {
+	if len(rule.byMonthDay) == 0 {
+		return true
+	}
+	last := day.AddDate(0, 1, -day.Day()).Day()
+	for _, monthDay := range rule.byMonthDay {
+		if monthDay == day.Day() || monthDay+last+1 == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// onWeekday whether the day is one of the days of the week of the rule
+func (rule *icsRule) onWeekday(day time.Time) bool {
+	if len(rule.byDay) == 0 {
+		return true
+	}
+	for _, weekday := range rule.byDay {
+		if day.Weekday() == weekday.day {
+			return true
+		}
+	}
+	return false
+}
+
+// icsOnDay the date, at the same time of day and zone as the start
+func icsOnDay(start time.Time, year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var icsFile = strings.ReplaceAll(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Example//Calendar//EN
+BEGIN:VEVENT
+UID:single-1
+DTSTART:20261012T090000Z
+DTEND:20261012T093000Z
+SUMMARY:Planning\, Q4
+DESCRIPTION:Agenda\nitems which are long enough that the line is folded o
+ ver two lines
+CATEGORIES:Meeting,Client\,A
+BEGIN:VALARM
+ACTION:DISPLAY
+DESCRIPTION:Reminder
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:standup
+DTSTART;TZID=Europe/London:20261005T100000
+DURATION:PT15M
+RRULE:FREQ=WEEKLY;BYDAY=MO,WE;COUNT=6
+EXDATE;TZID=Europe/London:20261007T100000
+SUMMARY:Standup
+END:VEVENT
+BEGIN:VEVENT
+UID:standup
+RECURRENCE-ID;TZID=Europe/London:20261012T100000
+DTSTART;TZID=Europe/London:20261012T110000
+DTEND;TZID=Europe/London:20261012T113000
+SUMMARY:Standup (moved)
+END:VEVENT
+BEGIN:VEVENT
+UID:declined-1
+DTSTART:20261013T140000Z
+DTEND:20261013T150000Z
+SUMMARY:Vendor demo
+ATTENDEE;CN=Alice;PARTSTAT=DECLINED:mailto:alice@example.com
+ATTENDEE;CN="Bob: Sales";PARTSTAT=ACCEPTED:mailto:bob@example.com
+END:VEVENT
+BEGIN:VEVENT
+UID:holiday
+DTSTART;VALUE=DATE:20261016
+DTEND;VALUE=DATE:20261017
+SUMMARY:Offsite
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled-1
+DTSTART:20261015T090000Z
+DTEND:20261015T100000Z
+SUMMARY:Cancelled
+STATUS:CANCELLED
+END:VEVENT
+BEGIN:VEVENT
+DTSTART:20261014T090000Z
+SUMMARY:No uid
+END:VEVENT
+BEGIN:VEVENT
+UID:later
+DTSTART:20261102T090000Z
+DTEND:20261102T100000Z
+SUMMARY:Next month
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n")
+
+func writeICSImportFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "import.ics")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func savedBySource(mockRepo *repository.MockRepo) map[string]*model.Work {
+	saved := make(map[string]*model.Work)
+	for _, call := range mockRepo.Calls {
+		if call.Method == "Save" {
+			wl := call.Arguments.Get(0).(*model.Work)
+			saved[wl.Source] = wl
+		}
+	}
+	return saved
+}
+
+func TestImportICSFrom(t *testing.T) {
+	october := time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name       string
+		options    *model.ICSImportOptions
+		existing   []*model.Work
+		expSources []string
+		expSkipped int
+	}{
+		{
+			name:    "Every event",
+			options: &model.ICSImportOptions{Start: october, End: october.AddDate(0, 1, 0)},
+			expSources: []string{
+				"ics:single-1",
+				"ics:standup/20261005T090000Z",
+				"ics:standup/20261012T090000Z",
+				"ics:standup/20261014T090000Z",
+				"ics:standup/20261019T090000Z",
+				"ics:standup/20261021T090000Z",
+				"ics:declined-1",
+				"ics:holiday",
+			},
+			expSkipped: 1,
+		}, {
+			name: "Skip declined and all day",
+			options: &model.ICSImportOptions{Start: october, End: october.AddDate(0, 1, 0),
+				Attendee: "alice@example.com", SkipDeclined: true, SkipAllDay: true},
+			expSources: []string{
+				"ics:single-1",
+				"ics:standup/20261005T090000Z",
+				"ics:standup/20261012T090000Z",
+				"ics:standup/20261014T090000Z",
+				"ics:standup/20261019T090000Z",
+				"ics:standup/20261021T090000Z",
+			},
+			expSkipped: 3,
+		}, {
+			name: "Declined by name",
+			options: &model.ICSImportOptions{Start: october.AddDate(0, 0, 12), End: october.AddDate(0, 0, 14),
+				Attendee: "alice", SkipDeclined: true},
+			expSources: []string{"ics:standup/20261014T090000Z"},
+			expSkipped: 1,
+		}, {
+			name:    "Range of recurrences",
+			options: &model.ICSImportOptions{Start: october.AddDate(0, 0, 13), End: october.AddDate(0, 0, 20)},
+			expSources: []string{
+				"ics:standup/20261014T090000Z",
+				"ics:standup/20261019T090000Z",
+				"ics:holiday",
+			},
+			expSkipped: 1,
+		}, {
+			name:    "Already imported",
+			options: &model.ICSImportOptions{Start: october.AddDate(0, 0, 11), End: october.AddDate(0, 0, 13)},
+			existing: []*model.Work{
+				{ID: importID("ics:single-1"), Revision: 1, Source: "ics:single-1"},
+			},
+			expSources: []string{"ics:standup/20261012T090000Z", "ics:declined-1"},
+			expSkipped: 1,
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return(testItem.existing, nil)
+		mockRepo.On("Save", mock.Anything).Return(nil)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			summary, code, err := svc.ImportICSFrom(writeICSImportFile(t, icsFile), testItem.options, ImportSkip, false)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, code)
+			assert.Equal(t, len(testItem.expSources), summary.Imported)
+			assert.Equal(t, testItem.expSkipped, summary.Skipped)
+			assert.Equal(t, []model.ImportError{{Record: 7, Error: e.ImportICSUID}}, summary.Errors)
+
+			saved := savedBySource(mockRepo)
+			assert.Len(t, saved, len(testItem.expSources))
+			for _, source := range testItem.expSources {
+				assert.Contains(t, saved, source)
+			}
+		})
+	}
+}
+
+func TestImportICSFromFields(t *testing.T) {
+	mockRepo := new(repository.MockRepo)
+	mockRepo.On("GetAll").Return([]*model.Work{}, nil)
+	mockRepo.On("Save", mock.Anything).Return(nil)
+	svc := NewWorklogService(mockRepo)
+
+	_, _, err := svc.ImportICSFrom(writeICSImportFile(t, icsFile), &model.ICSImportOptions{
+		Start: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC),
+	}, ImportSkip, false)
+	assert.Nil(t, err)
+	saved := savedBySource(mockRepo)
+
+	single := saved["ics:single-1"]
+	assert.Equal(t, importID("ics:single-1"), single.ID)
+	assert.Equal(t, 1, single.Revision)
+	assert.Equal(t, "Planning, Q4", single.Title)
+	assert.Equal(t, "Agenda\nitems which are long enough that the line is folded over two lines", single.Description)
+	assert.Equal(t, 30, single.Duration)
+	assert.Equal(t, []string{"Client,A", "Meeting"}, single.Tags)
+	assert.True(t, time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC).Equal(single.When))
+
+	standup := saved["ics:standup/20261005T090000Z"]
+	assert.Equal(t, "Standup", standup.Title)
+	assert.Equal(t, 15, standup.Duration)
+
+	moved := saved["ics:standup/20261012T090000Z"]
+	assert.Equal(t, "Standup (moved)", moved.Title)
+	assert.Equal(t, 30, moved.Duration)
+	assert.True(t, time.Date(2026, time.October, 12, 10, 0, 0, 0, time.UTC).Equal(moved.When))
+
+	holiday := saved["ics:holiday"]
+	assert.Equal(t, 24*60, holiday.Duration)
+	assert.True(t, time.Date(2026, time.October, 16, 0, 0, 0, 0, time.Local).Equal(holiday.When))
+}
+
+func TestImportICSFromErrors(t *testing.T) {
+	var tests = []struct {
+		name     string
+		content  string
+		strategy string
+		expCode  int
+		expErr   string
+	}{
+		{
+			name:     "Unknown strategy",
+			content:  icsFile,
+			strategy: "merge",
+			expCode:  http.StatusBadRequest,
+			expErr:   e.ImportStrategy,
+		}, {
+			name:     "Not a calendar",
+			content:  "id,title\n",
+			strategy: ImportSkip,
+			expCode:  http.StatusBadRequest,
+			expErr:   e.ImportICS,
+		},
+	}
+
+	for _, testItem := range tests {
+		svc := NewWorklogService(new(repository.MockRepo))
+
+		t.Run(testItem.name, func(t *testing.T) {
+			_, code, err := svc.ImportICSFrom(writeICSImportFile(t, testItem.content), nil, testItem.strategy, false)
+
+			assert.Equal(t, testItem.expCode, code)
+			assert.ErrorContains(t, err, testItem.expErr)
+		})
+	}
+}
+
+func TestImportICSFromUnsupportedRule(t *testing.T) {
+	mockRepo := new(repository.MockRepo)
+	mockRepo.On("GetAll").Return([]*model.Work{}, nil)
+	svc := NewWorklogService(mockRepo)
+
+	summary, code, err := svc.ImportICSFrom(writeICSImportFile(t, `BEGIN:VCALENDAR
+BEGIN:VEVENT
+UID:hourly
+DTSTART:20261012T090000Z
+RRULE:FREQ=HOURLY
+SUMMARY:Hourly
+END:VEVENT
+END:VCALENDAR
+`), nil, ImportSkip, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 0, summary.Imported)
+	assert.Len(t, summary.Errors, 1)
+	assert.Contains(t, summary.Errors[0].Error, e.ImportICSRecurrence)
+}
+
+func TestICSRuleExpand(t *testing.T) {
+	utc := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 9, 0, 0, 0, time.UTC)
+	}
+
+	var tests = []struct {
+		name   string
+		rule   string
+		start  time.Time
+		expect []time.Time
+	}{
+		{
+			name:   "Every other day",
+			rule:   "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			start:  utc(2026, time.October, 1),
+			expect: []time.Time{utc(2026, time.October, 1), utc(2026, time.October, 3), utc(2026, time.October, 5)},
+		}, {
+			name:  "Weekdays",
+			rule:  "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;COUNT=5",
+			start: utc(2026, time.October, 9),
+			expect: []time.Time{utc(2026, time.October, 9), utc(2026, time.October, 12),
+				utc(2026, time.October, 13), utc(2026, time.October, 14), utc(2026, time.October, 15)},
+		}, {
+			name:   "Weekly until a date",
+			rule:   "FREQ=WEEKLY;UNTIL=20261019",
+			start:  utc(2026, time.October, 5),
+			expect: []time.Time{utc(2026, time.October, 5), utc(2026, time.October, 12), utc(2026, time.October, 19)},
+		}, {
+			name:   "Last friday of the month",
+			rule:   "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			start:  utc(2026, time.October, 30),
+			expect: []time.Time{utc(2026, time.October, 30), utc(2026, time.November, 27), utc(2026, time.December, 25)},
+		}, {
+			name:   "Months with a 31st",
+			rule:   "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3",
+			start:  utc(2026, time.October, 31),
+			expect: []time.Time{utc(2026, time.October, 31), utc(2026, time.December, 31), utc(2027, time.January, 31)},
+		}, {
+			name:   "Second sunday of may",
+			rule:   "FREQ=YEARLY;BYMONTH=5;BYDAY=2SU;COUNT=2",
+			start:  utc(2027, time.May, 9),
+			expect: []time.Time{utc(2027, time.May, 9), utc(2028, time.May, 14)},
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			rule, err := parseICSRule(testItem.rule)
+			assert.Nil(t, err)
+
+			actual := rule.expand(testItem.start, utc(2030, time.January, 1))
+
+			assert.Equal(t, testItem.expect, actual)
+		})
+	}
+}
+
+func TestParseICSDuration(t *testing.T) {
+	var tests = []struct {
+		raw    string
+		expect time.Duration
+		expErr bool
+	}{
+		{raw: "PT15M", expect: 15 * time.Minute},
+		{raw: "P1DT2H30M", expect: 26*time.Hour + 30*time.Minute},
+		{raw: "P1W", expect: 7 * 24 * time.Hour},
+		{raw: "PT1H30M15S", expect: time.Hour + 30*time.Minute + 15*time.Second},
+		{raw: "-PT15M", expErr: true},
+		{raw: "P15M", expErr: true},
+		{raw: "PT15", expErr: true},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.raw, func(t *testing.T) {
+			actual, err := parseICSDuration(testItem.raw)
+
+			assert.Equal(t, testItem.expErr, err != nil)
+			assert.Equal(t, testItem.expect, actual)
+		})
+	}
+}
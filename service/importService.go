@@ -0,0 +1,162 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+// Strategies for importing a revision of work which is already stored
+const (
+	ImportSkip               = "skip"
+	ImportOverwrite          = "overwrite"
+	ImportKeepNewestRevision = "keep-newest-revision"
+)
+
+// importIDLength matches the length of IDs generated for new work
+const importIDLength = 20
+
+// importRecord a worklog to import, with its position in the import
+type importRecord struct {
+	record int
+	wl     *model.Work
+}
+
+// ValidImportStrategy whether the strategy is one of the import strategies
+func ValidImportStrategy(strategy string) bool {
+	return strategy == ImportSkip ||
+		strategy == ImportOverwrite ||
+		strategy == ImportKeepNewestRevision
+}
+
+func (s *service) ImportFrom(path, strategy string, dryRun bool) (*model.ImportSummary, int, error) {
+	if !ValidImportStrategy(strategy) {
+		return nil, http.StatusBadRequest, errors.New(e.ImportStrategy)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s. %s", e.ImportRead, err.Error())
+	}
+	var records []json.RawMessage
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s. %s", e.ImportFormat, err.Error())
+	}
+
+	summary := &model.ImportSummary{DryRun: dryRun}
+	worklogs := []importRecord{}
+	for index, record := range records {
+		var wl model.Work
+		if err := json.Unmarshal(record, &wl); err != nil {
+			summary.AddError(index+1, nil, fmt.Errorf("%s. %s", e.ImportRecordDecode, err.Error()))
+			continue
+		}
+		if err := validateImport(&wl); err != nil {
+			summary.AddError(index+1, &wl, err)
+			continue
+		}
+		worklogs = append(worklogs, importRecord{record: index + 1, wl: &wl})
+	}
+
+	return s.importWorklogs(worklogs, strategy, summary)
+}
+
+// importWorklogs saves each worklog based on the strategy for
+// revisions which are already stored
+func (*service) importWorklogs(worklogs []importRecord, strategy string, summary *model.ImportSummary) (*model.ImportSummary, int, error) {
+	existing, err := repo.GetAll()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	existingRevisions := make(map[string]map[int]bool)
+	latestRevisions := make(map[string]int)
+	for _, wl := range existing {
+		if existingRevisions[wl.ID] == nil {
+			existingRevisions[wl.ID] = make(map[int]bool)
+		}
+		existingRevisions[wl.ID][wl.Revision] = true
+		if wl.Revision > latestRevisions[wl.ID] {
+			latestRevisions[wl.ID] = wl.Revision
+		}
+	}
+
+	// Saved in order of revision, so the latest revision is stored last
+	sort.SliceStable(worklogs, func(i, j int) bool {
+		if worklogs[i].wl.ID != worklogs[j].wl.ID {
+			return worklogs[i].wl.ID < worklogs[j].wl.ID
+		}
+		return worklogs[i].wl.Revision < worklogs[j].wl.Revision
+	})
+
+	imported := make(map[string]map[int]bool)
+	for _, record := range worklogs {
+		wl := record.wl
+		if imported[wl.ID][wl.Revision] {
+			summary.AddError(record.record, wl, errors.New(e.ImportRecordDuplicate))
+			continue
+		}
+		if imported[wl.ID] == nil {
+			imported[wl.ID] = make(map[int]bool)
+		}
+		imported[wl.ID][wl.Revision] = true
+
+		if (strategy == ImportSkip && existingRevisions[wl.ID][wl.Revision]) ||
+			(strategy == ImportKeepNewestRevision && wl.Revision <= latestRevisions[wl.ID]) {
+			summary.Skipped++
+			continue
+		}
+		if !summary.DryRun {
+			if err := repo.Save(wl); err != nil {
+				summary.AddError(record.record, wl, err)
+				continue
+			}
+		}
+		summary.Imported++
+	}
+
+	sort.SliceStable(summary.Errors, func(i, j int) bool {
+		return summary.Errors[i].Record < summary.Errors[j].Record
+	})
+	return summary, http.StatusOK, nil
+}
+
+// importID generates the ID of work from what it was imported from,
+// so importing it again is treated as the same work
+func importID(from string) string {
+	hash := sha256.Sum256([]byte(from))
+	return hex.EncodeToString(hash[:])[:importIDLength]
+}
+
+// validateImport ensures an imported worklog is complete, and cleans
+// it the same way as newly created work
+func validateImport(wl *model.Work) error {
+	if wl.ID == "" {
+		return errors.New(e.ImportRecordID)
+	} else if !helpers.IsAlphaNumeric(wl.ID) {
+		return errors.New(e.ImportRecordIDInvalid)
+	} else if wl.Revision < 1 {
+		return errors.New(e.ImportRecordRevision)
+	}
+
+	wl.Sanitize()
+	wl.Tags = helpers.DeduplicateString(wl.Tags)
+	if wl.Title == "" {
+		return errors.New(e.ImportRecordTitle)
+	} else if wl.When.IsZero() {
+		return errors.New(e.ImportRecordWhen)
+	}
+	wl.WhenQueryEpoch = wl.When.Unix()
+	if wl.CreatedAt.IsZero() {
+		wl.CreatedAt = wl.When
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const importFile = `[
+	{"id": "aaa", "revision": 2, "title": "A edited", "when": "2026-10-12T09:00:00Z", "createdAt": "2026-10-13T09:00:00Z"},
+	{"id": "aaa", "revision": 1, "title": "A", "when": "2026-10-12T09:00:00Z", "createdAt": "2026-10-12T09:00:00Z"},
+	{"id": "bbb", "revision": 1, "title": "B", "tags": ["x", "x"], "when": "2026-10-12T10:00:00Z"},
+	{"id": "", "revision": 1, "title": "No id", "when": "2026-10-12T10:00:00Z"},
+	{"id": "ccc", "revision": 1, "title": "", "when": "2026-10-12T10:00:00Z"},
+	{"id": "ddd", "revision": 0, "title": "D", "when": "2026-10-12T10:00:00Z"},
+	{"id": "eee", "revision": 1, "title": "E"},
+	"not a worklog",
+	{"id": "bbb", "revision": 1, "title": "B again", "when": "2026-10-12T10:00:00Z"},
+	{"id": "../../x", "revision": 1, "title": "Outside", "when": "2026-10-12T10:00:00Z"}
+]`
+
+func writeImportFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "import.json")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestImportFrom(t *testing.T) {
+	path := writeImportFile(t, importFile)
+	existing := []*model.Work{
+		{ID: "aaa", Revision: 1, Title: "A", When: time.Now()},
+	}
+	expErrors := []model.ImportError{
+		{Record: 4, Revision: 1, Error: e.ImportRecordID},
+		{Record: 5, ID: "ccc", Revision: 1, Error: e.ImportRecordTitle},
+		{Record: 6, ID: "ddd", Error: e.ImportRecordRevision},
+		{Record: 7, ID: "eee", Revision: 1, Error: e.ImportRecordWhen},
+		{Record: 8, Error: e.ImportRecordDecode},
+		{Record: 9, ID: "bbb", Revision: 1, Error: e.ImportRecordDuplicate},
+		{Record: 10, ID: "../../x", Revision: 1, Error: e.ImportRecordIDInvalid},
+	}
+
+	var tests = []struct {
+		name        string
+		strategy    string
+		dryRun      bool
+		expSaved    []string
+		expImported int
+		expSkip     int
+	}{
+		{
+			name:        "Skip existing revisions",
+			strategy:    ImportSkip,
+			expSaved:    []string{"A edited", "B"},
+			expImported: 2,
+			expSkip:     1,
+		}, {
+			name:        "Overwrite existing revisions",
+			strategy:    ImportOverwrite,
+			expSaved:    []string{"A", "A edited", "B"},
+			expImported: 3,
+		}, {
+			name:        "Keep newest revision",
+			strategy:    ImportKeepNewestRevision,
+			expSaved:    []string{"A edited", "B"},
+			expImported: 2,
+			expSkip:     1,
+		}, {
+			name:        "Dry run saves nothing",
+			strategy:    ImportOverwrite,
+			dryRun:      true,
+			expSaved:    []string{},
+			expImported: 3,
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return(existing, nil)
+		mockRepo.On("Save", mock.Anything).Return(nil)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			summary, code, err := svc.ImportFrom(path, testItem.strategy, testItem.dryRun)
+
+			assert.Nil(t, err)
+			assert.Equal(t, http.StatusOK, code)
+			assert.Equal(t, testItem.dryRun, summary.DryRun)
+			assert.Equal(t, testItem.expSkip, summary.Skipped)
+			assert.Len(t, summary.Errors, len(expErrors))
+			for i, expErr := range expErrors {
+				assert.Equal(t, expErr.Record, summary.Errors[i].Record)
+				assert.Equal(t, expErr.ID, summary.Errors[i].ID)
+				assert.Contains(t, summary.Errors[i].Error, expErr.Error)
+			}
+			saved := []string{}
+			for _, call := range mockRepo.Calls {
+				if call.Method == "Save" {
+					wl := call.Arguments.Get(0).(*model.Work)
+					saved = append(saved, wl.Title)
+					if wl.ID == "bbb" {
+						assert.Equal(t, []string{"x"}, wl.Tags)
+						assert.Equal(t, wl.When, wl.CreatedAt)
+					}
+				}
+			}
+			assert.Equal(t, testItem.expSaved, saved)
+			assert.Equal(t, testItem.expImported, summary.Imported)
+		})
+	}
+}
+
+func TestImportFromErrors(t *testing.T) {
+	randErr := errors.New(helpers.RandAlphabeticString(strLength))
+
+	var tests = []struct {
+		name     string
+		content  string
+		strategy string
+		getErr   error
+		saveErr  error
+		expCode  int
+		expErr   bool
+		expRecs  int
+	}{
+		{
+			name:     "Unknown strategy",
+			content:  "[]",
+			strategy: "merge",
+			expCode:  http.StatusBadRequest,
+			expErr:   true,
+		}, {
+			name:     "Not a list",
+			content:  `{"id": "aaa"}`,
+			strategy: ImportSkip,
+			expCode:  http.StatusBadRequest,
+			expErr:   true,
+		}, {
+			name:     "Error getting existing",
+			content:  "[]",
+			strategy: ImportSkip,
+			getErr:   randErr,
+			expCode:  http.StatusInternalServerError,
+			expErr:   true,
+		}, {
+			name:     "Error saving is per record",
+			content:  `[{"id": "aaa", "revision": 1, "title": "A", "when": "2026-10-12T09:00:00Z"}]`,
+			strategy: ImportSkip,
+			saveErr:  randErr,
+			expCode:  http.StatusOK,
+			expRecs:  1,
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAll").Return([]*model.Work{}, testItem.getErr)
+		mockRepo.On("Save", mock.Anything).Return(testItem.saveErr)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			summary, code, err := svc.ImportFrom(writeImportFile(t, testItem.content), testItem.strategy, false)
+
+			assert.Equal(t, testItem.expCode, code)
+			assert.Equal(t, testItem.expErr, err != nil)
+			if !testItem.expErr {
+				assert.Len(t, summary.Errors, testItem.expRecs)
+				assert.Equal(t, 0, summary.Imported)
+			}
+		})
+	}
+}
+
+func TestImportFromMissingFile(t *testing.T) {
+	svc := NewWorklogService(new(repository.MockRepo))
+
+	_, code, err := svc.ImportFrom(filepath.Join(t.TempDir(), "missing.json"), ImportSkip, false)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.NotNil(t, err)
+}
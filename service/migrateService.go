@@ -0,0 +1,112 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+)
+
+// MigrateWorklogs copies every revision of work from one repository into
+// another, which must not already contain any work. Afterwards both
+// repositories are read back, and must hold the same revisions.
+// The repository migrated from is only read from.
+// Repositories can only list every revision at once, so every revision of
+// both is held in memory while checking, around twice the size of the work.
+func (*service) MigrateWorklogs(from, to repository.WorklogRepository) (*model.MigrateSummary, int, error) {
+	if err := to.Init(); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	existing, err := to.GetAll()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	} else if len(existing) != 0 {
+		return nil, http.StatusConflict, errors.New(e.MigrateNotEmpty)
+	}
+
+	revisions, err := from.GetAll()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	sortRevisions(revisions)
+
+	ids := make(map[string]bool)
+	for _, wl := range revisions {
+		if err := to.Save(wl); err != nil {
+			return nil, http.StatusInternalServerError, fmt.Errorf("%s %s, revision %d. %s",
+				e.RepoSaveFile, wl.ID, wl.Revision, err.Error())
+		}
+		ids[wl.ID] = true
+	}
+
+	migrated, err := to.GetAll()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	sortRevisions(migrated)
+	if len(migrated) != len(revisions) {
+		return nil, http.StatusInternalServerError, fmt.Errorf("%s, %d from and %d to",
+			e.MigrateCount, len(revisions), len(migrated))
+	}
+
+	checksum, err := checksumRevisions(revisions)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	migratedChecksum, err := checksumRevisions(migrated)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if checksum != migratedChecksum {
+		return nil, http.StatusInternalServerError, fmt.Errorf("%s, %s from and %s to",
+			e.MigrateChecksum, checksum, migratedChecksum)
+	}
+
+	return &model.MigrateSummary{
+		Worklogs:  len(ids),
+		Revisions: len(revisions),
+		Checksum:  checksum,
+	}, http.StatusOK, nil
+}
+
+func sortRevisions(w []*model.Work) {
+	sort.SliceStable(w, func(i, j int) bool {
+		if w[i].ID != w[j].ID {
+			return w[i].ID < w[j].ID
+		}
+		return w[i].Revision < w[j].Revision
+	})
+}
+
+// checksumRevisions generates a sha256 of the sorted revisions. Fields are
+// normalised first, as repositories differ in how they store times and
+// tags, and whether they derive the query epoch from when.
+func checksumRevisions(w []*model.Work) (string, error) {
+	hash := sha256.New()
+	for _, wl := range w {
+		normalised := *wl
+		normalised.When = normalised.When.UTC()
+		normalised.CreatedAt = normalised.CreatedAt.UTC()
+		normalised.WhenQueryEpoch = normalised.When.Unix()
+		normalised.Tags = nil
+		if len(wl.Tags) != 0 {
+			normalised.Tags = append([]string{}, wl.Tags...)
+			sort.Strings(normalised.Tags)
+		}
+		b, err := json.Marshal(normalised)
+		if err != nil {
+			return "", err
+		}
+		if _, err := hash.Write(append(b, '\n')); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func migrateRevisions() []*model.Work {
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+	return []*model.Work{
+		{ID: "bbb", Revision: 1, Title: "B", Tags: []string{"y", "x"}, When: when, CreatedAt: when},
+		{ID: "aaa", Revision: 2, Title: "A edited", When: when, CreatedAt: when.Add(time.Hour)},
+		{ID: "aaa", Revision: 1, Title: "A", Tags: []string{}, When: when, CreatedAt: when},
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	local := migrateRevisions()
+	for _, wl := range local {
+		wl.When = wl.When.Local()
+		wl.CreatedAt = wl.CreatedAt.Local()
+	}
+	changed := migrateRevisions()
+	changed[0].Title = "C"
+
+	var tests = []struct {
+		name     string
+		existing []*model.Work
+		migrated []*model.Work
+		saveErr  error
+		expCode  int
+		expErr   string
+	}{
+		{
+			name:     "Migrates all revisions",
+			migrated: local,
+			expCode:  http.StatusOK,
+		}, {
+			name:     "Repository not empty",
+			existing: migrateRevisions()[:1],
+			expCode:  http.StatusConflict,
+			expErr:   e.MigrateNotEmpty,
+		}, {
+			name:    "Error saving",
+			saveErr: errors.New(helpers.RandAlphabeticString(strLength)),
+			expCode: http.StatusInternalServerError,
+			expErr:  e.RepoSaveFile,
+		}, {
+			name:     "Different number of revisions",
+			migrated: migrateRevisions()[1:],
+			expCode:  http.StatusInternalServerError,
+			expErr:   e.MigrateCount,
+		}, {
+			name:     "Different revisions",
+			migrated: changed,
+			expCode:  http.StatusInternalServerError,
+			expErr:   e.MigrateChecksum,
+		},
+	}
+
+	for _, testItem := range tests {
+		fromRepo := new(repository.MockRepo)
+		fromRepo.On("GetAll").Return(migrateRevisions(), nil)
+		toRepo := new(repository.MockRepo)
+		toRepo.On("Init").Return(nil)
+		toRepo.On("GetAll").Return(testItem.existing, nil).Once()
+		toRepo.On("GetAll").Return(testItem.migrated, nil).Once()
+		toRepo.On("Save", mock.Anything).Return(testItem.saveErr)
+		svc := NewWorklogService(fromRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			summary, code, err := svc.MigrateWorklogs(fromRepo, toRepo)
+
+			assert.Equal(t, testItem.expCode, code)
+			if testItem.expErr != "" {
+				assert.Contains(t, err.Error(), testItem.expErr)
+				assert.Nil(t, summary)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, 2, summary.Worklogs)
+			assert.Equal(t, 3, summary.Revisions)
+			assert.Len(t, summary.Checksum, 64)
+
+			saved := []string{}
+			for _, call := range toRepo.Calls {
+				if call.Method == "Save" {
+					saved = append(saved, call.Arguments.Get(0).(*model.Work).Title)
+				}
+			}
+			assert.Equal(t, []string{"A", "A edited", "B"}, saved)
+			fromRepo.AssertNotCalled(t, "Save", mock.Anything)
+		})
+	}
+}
+
+func TestMigrateBetweenRepositories(t *testing.T) {
+	legacy := repository.NewYamlFileRepo(filepath.Join(t.TempDir(), "legacy"))
+	assert.Nil(t, legacy.Init())
+	for _, wl := range migrateRevisions() {
+		assert.Nil(t, legacy.Save(wl))
+	}
+
+	svc := NewWorklogService(legacy)
+	bolt := repository.NewBBoltRepo(filepath.Join(t.TempDir(), "worklog.db"))
+	toBolt, code, err := svc.MigrateWorklogs(legacy, bolt)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	sqlite := repository.NewSQLiteRepo(filepath.Join(t.TempDir(), "worklog.sqlite"))
+	toSQLite, code, err := svc.MigrateWorklogs(bolt, sqlite)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	jsonl := repository.NewJSONLRepo(filepath.Join(t.TempDir(), "worklog.jsonl"))
+	toJSONL, code, err := svc.MigrateWorklogs(sqlite, jsonl)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	git := repository.NewGitRepo(filepath.Join(t.TempDir(), "git"))
+	toGit, code, err := svc.MigrateWorklogs(jsonl, git)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	back := repository.NewYamlFileRepo(filepath.Join(t.TempDir(), "legacy"))
+	toLegacy, code, err := svc.MigrateWorklogs(git, back)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	assert.Equal(t, 3, toBolt.Revisions)
+	assert.Equal(t, toBolt, toSQLite)
+	assert.Equal(t, toBolt, toJSONL)
+	assert.Equal(t, toBolt, toGit)
+	assert.Equal(t, toBolt, toLegacy)
+
+	original, err := legacy.GetAll()
+	assert.Nil(t, err)
+	migrated, err := back.GetAll()
+	assert.Nil(t, err)
+	assert.Len(t, migrated, len(original))
+	for i, wl := range migrated {
+		assert.Equal(t, original[i].ID, wl.ID)
+		assert.Equal(t, original[i].Revision, wl.Revision)
+		assert.Equal(t, original[i].Title, wl.Title)
+		assert.Equal(t, original[i].Tags, wl.Tags)
+		assert.True(t, original[i].CreatedAt.Equal(wl.CreatedAt))
+	}
+
+	_, code, err = svc.MigrateWorklogs(legacy, bolt)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.EqualError(t, err, e.MigrateNotEmpty)
+}
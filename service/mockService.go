@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -24,6 +25,18 @@ func (m *MockService) EditWorklog(id string, newWl *model.Work) (*model.Work, in
 	return nil, args.Int(0), args.Error(1)
 }
 
+// DeleteWorklog WorklogService method for testing
+func (m *MockService) DeleteWorklog(id string, purge bool) (int, error) {
+	args := m.Called(id, purge)
+	return args.Int(0), args.Error(1)
+}
+
+// RevertWorklog WorklogService method for testing
+func (m *MockService) RevertWorklog(id string, revision int) (*model.Work, int, error) {
+	args := m.Called(id, revision)
+	return nil, args.Int(0), args.Error(1)
+}
+
 // GetWorklogsBetween WorklogService method for testing
 func (m *MockService) GetWorklogsBetween(startDate, endDate time.Time, filter *model.Work) ([]*model.Work, int, error) {
 	args := m.Called(startDate, endDate, filter)
@@ -36,8 +49,92 @@ func (m *MockService) GetWorklogsByID(filter *model.Work, ids ...string) ([]*mod
 	return args.Get(0).([]*model.Work), args.Int(1), args.Error(2)
 }
 
+// GetWorklogRevisions WorklogService method for testing
+func (m *MockService) GetWorklogRevisions(id string) ([]*model.Work, int, error) {
+	args := m.Called(id)
+	return args.Get(0).([]*model.Work), args.Int(1), args.Error(2)
+}
+
+// Summarise WorklogService method for testing
+func (m *MockService) Summarise(start, end time.Time, filter *model.Work, groupBy []string) (*model.Summary, int, error) {
+	args := m.Called(start, end, filter, groupBy)
+	return args.Get(0).(*model.Summary), args.Int(1), args.Error(2)
+}
+
+// GetStandup WorklogService method for testing
+func (m *MockService) GetStandup(now time.Time, filter *model.Work) (*model.Standup, int, error) {
+	args := m.Called(now, filter)
+	return args.Get(0).(*model.Standup), args.Int(1), args.Error(2)
+}
+
+// StartTimer WorklogService method for testing
+func (m *MockService) StartTimer(wl *model.Work) (int, error) {
+	args := m.Called(wl)
+	return args.Int(0), args.Error(1)
+}
+
+// StopTimer WorklogService method for testing
+func (m *MockService) StopTimer() (*model.Work, int, error) {
+	args := m.Called()
+	return args.Get(0).(*model.Work), args.Int(1), args.Error(2)
+}
+
+// GetTimer WorklogService method for testing
+func (m *MockService) GetTimer() (*model.Work, int, error) {
+	args := m.Called()
+	return args.Get(0).(*model.Work), args.Int(1), args.Error(2)
+}
+
 // ExportTo WorklogService method for testing
-func (m *MockService) ExportTo(path string) (int, error) {
-	args := m.Called(path)
+func (m *MockService) ExportTo(path, format string, start, end time.Time, filter *model.Work, latestOnly bool) (int, error) {
+	args := m.Called(path, format, start, end, filter, latestOnly)
 	return args.Int(0), args.Error(1)
 }
+
+// ImportFrom WorklogService method for testing
+func (m *MockService) ImportFrom(path, strategy string, dryRun bool) (*model.ImportSummary, int, error) {
+	args := m.Called(path, strategy, dryRun)
+	return args.Get(0).(*model.ImportSummary), args.Int(1), args.Error(2)
+}
+
+// ImportCSVFrom WorklogService method for testing
+func (m *MockService) ImportCSVFrom(path string, mapping *model.ImportMapping, strategy string, dryRun bool) (*model.ImportSummary, int, error) {
+	args := m.Called(path, mapping, strategy, dryRun)
+	return args.Get(0).(*model.ImportSummary), args.Int(1), args.Error(2)
+}
+
+// ImportICSFrom WorklogService method for testing
+func (m *MockService) ImportICSFrom(path string, options *model.ICSImportOptions, strategy string, dryRun bool) (*model.ImportSummary, int, error) {
+	args := m.Called(path, options, strategy, dryRun)
+	return args.Get(0).(*model.ImportSummary), args.Int(1), args.Error(2)
+}
+
+// ProposeGitWorklogs WorklogService method for testing
+func (m *MockService) ProposeGitWorklogs(path string, since time.Time, author string) ([]*model.Work, int, error) {
+	args := m.Called(path, since, author)
+	return args.Get(0).([]*model.Work), args.Int(1), args.Error(2)
+}
+
+// SyncWorklogs WorklogService method for testing
+func (m *MockService) SyncWorklogs(remoteURL, strategy string) (*model.SyncSummary, int, error) {
+	args := m.Called(remoteURL, strategy)
+	return args.Get(0).(*model.SyncSummary), args.Int(1), args.Error(2)
+}
+
+// PullRevisions WorklogService method for testing
+func (m *MockService) PullRevisions(marks map[string]int) ([]*model.Work, int, error) {
+	args := m.Called(marks)
+	return args.Get(0).([]*model.Work), args.Int(1), args.Error(2)
+}
+
+// PushRevisions WorklogService method for testing
+func (m *MockService) PushRevisions(wls []*model.Work) ([]string, int, error) {
+	args := m.Called(wls)
+	return args.Get(0).([]string), args.Int(1), args.Error(2)
+}
+
+// MigrateWorklogs WorklogService method for testing
+func (m *MockService) MigrateWorklogs(from, to repository.WorklogRepository) (*model.MigrateSummary, int, error) {
+	args := m.Called(from, to)
+	return args.Get(0).(*model.MigrateSummary), args.Int(1), args.Error(2)
+}
@@ -4,14 +4,34 @@ import (
 	"time"
 
 	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
 )
 
 // WorklogService defines what a service for worklogs should be capable of doing
 type WorklogService interface {
 	CreateWorklog(wl *model.Work) (int, error)
 	EditWorklog(id string, newWl *model.Work) (*model.Work, int, error)
+	DeleteWorklog(id string, purge bool) (int, error)
+	RevertWorklog(id string, revision int) (*model.Work, int, error)
 	GetWorklogsBetween(start, end time.Time, filter *model.Work) ([]*model.Work, int, error)
 	GetWorklogsByID(filter *model.Work, ids ...string) ([]*model.Work, int, error)
+	GetWorklogRevisions(id string) ([]*model.Work, int, error)
+	Summarise(start, end time.Time, filter *model.Work, groupBy []string) (*model.Summary, int, error)
+	GetStandup(now time.Time, filter *model.Work) (*model.Standup, int, error)
 
-	ExportTo(path string) (int, error)
+	StartTimer(wl *model.Work) (int, error)
+	StopTimer() (*model.Work, int, error)
+	GetTimer() (*model.Work, int, error)
+
+	ExportTo(path, format string, start, end time.Time, filter *model.Work, latestOnly bool) (int, error)
+	ImportFrom(path, strategy string, dryRun bool) (*model.ImportSummary, int, error)
+	ImportCSVFrom(path string, mapping *model.ImportMapping, strategy string, dryRun bool) (*model.ImportSummary, int, error)
+	ImportICSFrom(path string, options *model.ICSImportOptions, strategy string, dryRun bool) (*model.ImportSummary, int, error)
+	ProposeGitWorklogs(path string, since time.Time, author string) ([]*model.Work, int, error)
+
+	SyncWorklogs(remoteURL, strategy string) (*model.SyncSummary, int, error)
+	PullRevisions(marks map[string]int) ([]*model.Work, int, error)
+	PushRevisions(wls []*model.Work) ([]string, int, error)
+
+	MigrateWorklogs(from, to repository.WorklogRepository) (*model.MigrateSummary, int, error)
 }
@@ -0,0 +1,25 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+)
+
+func (s *service) GetStandup(now time.Time, filter *model.Work) (*model.Standup, int, error) {
+	yesterday := helpers.GetPreviousWorkingDay(now)
+	today := helpers.Midnight(now)
+
+	yesterdayWork, code, err := s.GetWorklogsBetween(yesterday, yesterday.AddDate(0, 0, 1), filter)
+	if err != nil {
+		return nil, code, err
+	}
+	todayWork, code, err := s.GetWorklogsBetween(today, today.AddDate(0, 0, 1), filter)
+	if err != nil {
+		return nil, code, err
+	}
+	// An empty standup is still valid, so isn't treated as not found
+	return model.NewStandup(yesterday, yesterdayWork, today, todayWork), http.StatusOK, nil
+}
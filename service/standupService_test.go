@@ -0,0 +1,78 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStandup(t *testing.T) {
+	randErr := errors.New(helpers.RandAlphabeticString(strLength))
+	monday := time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+	friday := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	mondayMidnight := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	wlYesterday := genWl()
+	wlToday := genWl()
+
+	var tests = []struct {
+		name       string
+		yesterday  []*model.Work
+		today      []*model.Work
+		todayErr   error
+		expStandup *model.Standup
+		expCode    int
+		expErr     error
+	}{
+		{
+			name:      "Work on both days",
+			yesterday: []*model.Work{wlYesterday},
+			today:     []*model.Work{wlToday},
+			expStandup: model.NewStandup(
+				friday, []*model.Work{wlYesterday},
+				mondayMidnight, []*model.Work{wlToday}),
+			expCode: http.StatusOK,
+		}, {
+			name:      "No work",
+			yesterday: []*model.Work{},
+			today:     []*model.Work{},
+			expStandup: model.NewStandup(
+				friday, []*model.Work{},
+				mondayMidnight, []*model.Work{}),
+			expCode: http.StatusOK,
+		}, {
+			name:      "Error getting work",
+			yesterday: []*model.Work{},
+			today:     []*model.Work{},
+			todayErr:  randErr,
+			expCode:   http.StatusInternalServerError,
+			expErr:    randErr,
+		},
+	}
+
+	for _, testItem := range tests {
+		filter := &model.Work{Author: "Alice"}
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAllBetweenDates", friday, friday.AddDate(0, 0, 1), filter).
+			Return(testItem.yesterday, nil)
+		mockRepo.On("GetAllBetweenDates", mondayMidnight, mondayMidnight.AddDate(0, 0, 1), filter).
+			Return(testItem.today, testItem.todayErr)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			standup, code, err := svc.GetStandup(monday, filter)
+
+			assert.Equal(t, testItem.expCode, code)
+			assert.Equal(t, testItem.expErr, err)
+			assert.Equal(t, testItem.expStandup, standup)
+			mockRepo.AssertNumberOfCalls(t, "GetAllBetweenDates", 2)
+			mockRepo.AssertCalled(t, "GetAllBetweenDates", friday, friday.AddDate(0, 0, 1), filter)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/PossibleLlama/worklog/model"
+)
+
+func (s *service) Summarise(start, end time.Time, filter *model.Work, groupBy []string) (*model.Summary, int, error) {
+	// Validate the grouping before reading any work
+	if _, err := model.NewSummary([]*model.Work{}, groupBy); err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	worklogs, code, err := s.GetWorklogsBetween(start, end, filter)
+	if err != nil {
+		return nil, code, err
+	}
+	summary, _ := model.NewSummary(worklogs, groupBy)
+	return summary, code, nil
+}
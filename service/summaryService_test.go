@@ -0,0 +1,80 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSummarise(t *testing.T) {
+	randErr := errors.New(helpers.RandAlphabeticString(strLength))
+	wlA := genWl()
+	wlA.Duration = 30
+	wlB := genWl()
+	wlB.Duration = 45
+
+	var tests = []struct {
+		name       string
+		groupBy    []string
+		repoWls    []*model.Work
+		repoErr    error
+		callRepo   bool
+		expSummary *model.Summary
+		expCode    int
+		expErr     bool
+	}{
+		{
+			name:       "Totals work",
+			repoWls:    []*model.Work{wlA, wlB},
+			callRepo:   true,
+			expSummary: &model.Summary{Count: 2, Duration: 75},
+			expCode:    http.StatusOK,
+		}, {
+			name:       "No work found",
+			repoWls:    []*model.Work{},
+			callRepo:   true,
+			expSummary: &model.Summary{},
+			expCode:    http.StatusNotFound,
+		}, {
+			name:     "Error getting work",
+			repoWls:  []*model.Work{},
+			repoErr:  randErr,
+			callRepo: true,
+			expCode:  http.StatusInternalServerError,
+			expErr:   true,
+		}, {
+			name:    "Unknown grouping",
+			groupBy: []string{"year"},
+			expCode: http.StatusBadRequest,
+			expErr:  true,
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetAllBetweenDates", mock.Anything, mock.Anything, mock.Anything).
+			Return(testItem.repoWls, testItem.repoErr)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			summary, code, err := svc.Summarise(time.Time{}, time.Now(), &model.Work{}, testItem.groupBy)
+
+			assert.Equal(t, testItem.expCode, code)
+			assert.Equal(t, testItem.expErr, err != nil)
+			assert.Equal(t, testItem.expSummary, summary)
+			if testItem.callRepo {
+				mockRepo.AssertCalled(t, "GetAllBetweenDates", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				mockRepo.AssertNotCalled(t, "GetAllBetweenDates", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/spf13/viper"
+)
+
+// Strategies for resolving worklogs changed both locally and in the server
+const (
+	SyncKeepLocal  = "keep-local"
+	SyncKeepRemote = "keep-remote"
+	SyncKeepNewest = "keep-newest"
+)
+
+// ValidSyncStrategy whether the strategy is one of the sync strategies.
+// Without a strategy, conflicts are left for the user to resolve.
+func ValidSyncStrategy(strategy string) bool {
+	return strategy == "" ||
+		strategy == SyncKeepLocal ||
+		strategy == SyncKeepRemote ||
+		strategy == SyncKeepNewest
+}
+
+// SyncWorklogs shares worklogs with the remote of the repository, if
+// the repository is able to. Git repositories sync with their own remote,
+// set to the url if provided, while other repositories sync with the
+// worklog-server at the url, defaulting to the configured sync.url.
+func (*service) SyncWorklogs(remoteURL, strategy string) (*model.SyncSummary, int, error) {
+	remoteURL = strings.TrimSpace(remoteURL)
+	if !ValidSyncStrategy(strategy) {
+		return nil, http.StatusBadRequest, errors.New(e.SyncStrategy)
+	}
+
+	var summary *model.SyncSummary
+	var err error
+	if syncs, ok := repo.(repository.SyncRepository); ok {
+		if strategy != "" {
+			return nil, http.StatusBadRequest, errors.New(e.SyncStrategyGit)
+		}
+		summary, err = syncs.Sync(remoteURL)
+	} else if marks, ok := repo.(repository.SyncMarkRepository); ok {
+		if remoteURL == "" {
+			remoteURL = viper.GetString("sync.url")
+		}
+		if remoteURL == "" {
+			return nil, http.StatusBadRequest, errors.New(e.RepoSyncNoRemote)
+		}
+		remote := repository.NewRemoteRepo(remoteURL, viper.GetString("sync.token"))
+		summary, err = syncReplica(repo, marks, remote.(repository.ReplicaRepository), remoteURL, strategy)
+	} else {
+		return nil, http.StatusNotImplemented, errors.New(e.RepoSyncUnsupported)
+	}
+
+	if err != nil {
+		if err.Error() == e.RepoSyncNoRemote {
+			return summary, http.StatusBadRequest, err
+		} else if strings.HasPrefix(err.Error(), e.RepoSyncConflict) {
+			return summary, http.StatusConflict, err
+		}
+		return summary, http.StatusInternalServerError, err
+	}
+	return summary, http.StatusOK, nil
+}
+
+// PullRevisions every revision of each worklog after the
+// revision marked for its ID, including deleted worklogs
+func (*service) PullRevisions(marks map[string]int) ([]*model.Work, int, error) {
+	revs, err := replica{repo: repo}.RevisionsAfter(marks)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return revs, http.StatusOK, nil
+}
+
+// PushRevisions saves revisions exactly as they are, returning the IDs
+// of any which would replace a different revision, in which case
+// nothing is saved
+func (*service) PushRevisions(wls []*model.Work) ([]string, int, error) {
+	for _, wl := range wls {
+		if err := validateImport(wl); err != nil {
+			return nil, http.StatusBadRequest, err
+		}
+	}
+	conflicts, err := replica{repo: repo}.saveRevisions(wls)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	} else if len(conflicts) != 0 {
+		return conflicts, http.StatusConflict, errors.New(e.RepoSyncConflict)
+	}
+	return []string{}, http.StatusOK, nil
+}
+
+// syncReplica pushes revisions changed locally since they were last
+// synced with the remote, and pulls those changed in the remote.
+// Worklogs with different revisions of the same number in both are
+// resolved by the strategy, otherwise they are left unsynced.
+func syncReplica(local repository.WorklogRepository, marks repository.SyncMarkRepository, remote repository.ReplicaRepository, remoteName, strategy string) (*model.SyncSummary, error) {
+	synced, err := marks.GetSyncMarks(remoteName)
+	if err != nil {
+		return nil, err
+	}
+	all, err := local.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	helpers.LogDebug("Fetching worklogs...", "sync - server")
+	pulled, err := remote.RevisionsAfter(synced)
+	if err != nil {
+		return nil, fmt.Errorf("%s. %s", e.RepoSync, err.Error())
+	}
+	ours := revisionsAfter(all, synced)
+	theirs := revisionsAfter(pulled, synced)
+
+	ids := []string{}
+	for id := range ours {
+		ids = append(ids, id)
+	}
+	for id := range theirs {
+		if _, ok := ours[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	summary := &model.SyncSummary{}
+	save := []*model.Work{}
+	push := []*model.Work{}
+	latest := make(map[string]int)
+	for _, id := range ids {
+		if !conflicting(ours[id], theirs[id]) {
+			save = append(save, missingRevisions(theirs[id], ours[id])...)
+			push = append(push, missingRevisions(ours[id], theirs[id])...)
+		} else if winner := resolveConflict(ours[id], theirs[id], strategy); winner != nil {
+			s, p := resolvedRevisions(ours[id], theirs[id], winner)
+			save = append(save, s...)
+			push = append(push, p...)
+			summary.Resolved++
+		} else {
+			summary.Conflicts = append(summary.Conflicts, id)
+			continue
+		}
+		for _, wl := range ours[id] {
+			latest[id] = max(latest[id], wl.Revision)
+		}
+		for _, wl := range theirs[id] {
+			latest[id] = max(latest[id], wl.Revision)
+		}
+	}
+	// Resolving conflicts can add a revision
+	for _, wl := range push {
+		latest[wl.ID] = max(latest[wl.ID], wl.Revision)
+	}
+
+	if len(push) != 0 {
+		helpers.LogDebug("Pushing worklogs...", "sync - server")
+		if err := remote.SaveRevisions(push); err != nil {
+			return nil, err
+		}
+	}
+	for _, wl := range save {
+		wl.Sanitize()
+		if err := local.Save(wl); err != nil {
+			return nil, err
+		}
+	}
+	for id, revision := range latest {
+		synced[id] = revision
+	}
+	if err := marks.SaveSyncMarks(remoteName, synced); err != nil {
+		return nil, err
+	}
+
+	summary.Pulled = len(save)
+	summary.Pushed = len(push)
+	if len(summary.Conflicts) != 0 {
+		return summary, fmt.Errorf("%s: %s", e.RepoSyncConflict, strings.Join(summary.Conflicts, ", "))
+	}
+	return summary, nil
+}
+
+// revisionsAfter groups the revisions after the one marked for their ID,
+// sorted by revision
+func revisionsAfter(wls []*model.Work, marks map[string]int) map[string][]*model.Work {
+	byID := make(map[string][]*model.Work)
+	for _, wl := range wls {
+		if wl.Revision > marks[wl.ID] {
+			byID[wl.ID] = append(byID[wl.ID], wl)
+		}
+	}
+	for _, revs := range byID {
+		sort.SliceStable(revs, func(i, j int) bool {
+			return revs[i].Revision < revs[j].Revision
+		})
+	}
+	return byID
+}
+
+// sameContent whether both revisions hold the same work, regardless
+// of when each was created
+func sameContent(a, b *model.Work) bool {
+	return len(model.NewRevisionDiff(a, b).Changes) == 0
+}
+
+// conflicting whether any revision is in both with different content
+func conflicting(ours, theirs []*model.Work) bool {
+	for _, our := range ours {
+		for _, their := range theirs {
+			if our.Revision == their.Revision && !sameContent(our, their) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// missingRevisions the revisions of from which aren't in revs
+func missingRevisions(from, revs []*model.Work) []*model.Work {
+	missing := []*model.Work{}
+	for _, wl := range from {
+		found := false
+		for _, rev := range revs {
+			found = found || rev.Revision == wl.Revision
+		}
+		if !found {
+			missing = append(missing, wl)
+		}
+	}
+	return missing
+}
+
+// resolveConflict the latest revision of the side kept by the strategy,
+// or nil if the conflict is left for the user
+func resolveConflict(ours, theirs []*model.Work, strategy string) *model.Work {
+	our, their := ours[len(ours)-1], theirs[len(theirs)-1]
+	switch strategy {
+	case SyncKeepLocal:
+		return our
+	case SyncKeepRemote:
+		return their
+	case SyncKeepNewest:
+		if our.CreatedAt.After(their.CreatedAt) {
+			return our
+		}
+		return their
+	}
+	return nil
+}
+
+// resolvedRevisions keeps the remote's revisions, followed by any later
+// local revisions. If the latest of those isn't the winner, a new revision
+// with the content of the winner is added to both.
+func resolvedRevisions(ours, theirs []*model.Work, winner *model.Work) ([]*model.Work, []*model.Work) {
+	save := append([]*model.Work{}, theirs...)
+	push := []*model.Work{}
+	latest := theirs[len(theirs)-1]
+	for _, wl := range ours {
+		if wl.Revision > latest.Revision {
+			push = append(push, wl)
+		}
+	}
+	if len(push) != 0 {
+		latest = push[len(push)-1]
+	}
+
+	if !sameContent(latest, winner) {
+		next := *latest
+		next.RevertTo(*winner)
+		save = append(save, &next)
+		push = append(push, &next)
+	}
+	return save, push
+}
+
+// replica copies revisions to and from the repository exactly as they are
+type replica struct {
+	repo repository.WorklogRepository
+}
+
+func (r replica) RevisionsAfter(marks map[string]int) ([]*model.Work, error) {
+	all, err := r.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	revs := []*model.Work{}
+	for _, wl := range all {
+		if wl.Revision > marks[wl.ID] {
+			revs = append(revs, wl)
+		}
+	}
+	return revs, nil
+}
+
+func (r replica) SaveRevisions(wls []*model.Work) error {
+	conflicts, err := r.saveRevisions(wls)
+	if err != nil {
+		return err
+	} else if len(conflicts) != 0 {
+		return fmt.Errorf("%s: %s", e.RepoSyncConflict, strings.Join(conflicts, ", "))
+	}
+	return nil
+}
+
+// saveRevisions saves the revisions which aren't already stored, unless any
+// would replace a different revision, returning the IDs of those instead
+func (r replica) saveRevisions(wls []*model.Work) ([]string, error) {
+	all, err := r.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]*model.Work)
+	for _, wl := range all {
+		existing[fmt.Sprintf("%s_%d", wl.ID, wl.Revision)] = wl
+	}
+
+	conflicts := []string{}
+	save := []*model.Work{}
+	for _, wl := range wls {
+		stored, ok := existing[fmt.Sprintf("%s_%d", wl.ID, wl.Revision)]
+		if !ok {
+			save = append(save, wl)
+		} else if !sameContent(stored, wl) {
+			conflicts = append(conflicts, wl.ID)
+		}
+	}
+	if len(conflicts) != 0 {
+		return helpers.DeduplicateString(conflicts), nil
+	}
+
+	// Saved in order of revision, so the latest revision is stored last
+	sort.SliceStable(save, func(i, j int) bool {
+		if save[i].ID != save[j].ID {
+			return save[i].ID < save[j].ID
+		}
+		return save[i].Revision < save[j].Revision
+	})
+	for _, wl := range save {
+		wl.WhenQueryEpoch = wl.When.Unix()
+		if err := r.repo.Save(wl); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncWorklogsUnsupported(t *testing.T) {
+	svc := NewWorklogService(new(repository.MockRepo))
+
+	summary, code, err := svc.SyncWorklogs("", "")
+
+	assert.Nil(t, summary)
+	assert.Equal(t, http.StatusNotImplemented, code)
+	assert.EqualError(t, err, e.RepoSyncUnsupported)
+}
+
+func TestSyncWorklogsWithoutRemote(t *testing.T) {
+	svc := NewWorklogService(repository.NewGitRepo(filepath.Join(t.TempDir(), "git")))
+
+	_, code, err := svc.SyncWorklogs("", "")
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.EqualError(t, err, e.RepoSyncNoRemote)
+}
+
+func TestSyncWorklogs(t *testing.T) {
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	assert.Nil(t, exec.Command("git", "init", "-q", "--bare", "-b", "main", remote).Run())
+	alice := repository.NewGitRepo(filepath.Join(t.TempDir(), "alice"))
+	bob := repository.NewGitRepo(filepath.Join(t.TempDir(), "bob"))
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+
+	// Worklogs created separately by each are shared
+	svc := NewWorklogService(alice)
+	shared := model.NewWork("Shared", "", "Alice", 30, nil, when)
+	_, err := svc.CreateWorklog(shared)
+	assert.Nil(t, err)
+	summary, code, err := svc.SyncWorklogs(remote, "")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, &model.SyncSummary{Pushed: 1}, summary)
+
+	svc = NewWorklogService(bob)
+	_, err = svc.CreateWorklog(model.NewWork("By Bob", "", "Bob", 15, nil, when))
+	assert.Nil(t, err)
+	summary, _, err = svc.SyncWorklogs(remote, "")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.SyncSummary{Pulled: 1, Pushed: 1}, summary, "worklog revisions are counted, not commits")
+	found, _, err := svc.GetWorklogsBetween(time.Time{}, time.Time{}, &model.Work{})
+	assert.Nil(t, err)
+	assert.Len(t, found, 2)
+
+	// The highest revision is kept when the revisions in both are the same
+	_, _, err = svc.EditWorklog(shared.ID, &model.Work{Title: "Same edit"})
+	assert.Nil(t, err)
+	_, _, err = svc.SyncWorklogs("", "")
+	assert.Nil(t, err)
+
+	svc = NewWorklogService(alice)
+	_, _, err = svc.EditWorklog(shared.ID, &model.Work{Title: "Same edit"})
+	assert.Nil(t, err)
+	_, _, err = svc.EditWorklog(shared.ID, &model.Work{Title: "Later edit by Alice"})
+	assert.Nil(t, err)
+	summary, code, err = svc.SyncWorklogs("", "")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	// Bob's worklog and his revision of the shared worklog are pulled
+	assert.Equal(t, &model.SyncSummary{Pulled: 2, Pushed: 1, Resolved: 1}, summary)
+	found, _, err = svc.GetWorklogsByID(&model.Work{}, shared.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Later edit by Alice", found[0].Title)
+	revisions, _, err := svc.GetWorklogRevisions(shared.ID)
+	assert.Nil(t, err)
+	assert.Len(t, revisions, 3)
+
+	// The same revision changed by both can't be resolved, even once
+	// one of them has made later revisions
+	svc = NewWorklogService(bob)
+	_, _, err = svc.SyncWorklogs("", "")
+	assert.Nil(t, err)
+	_, _, err = svc.EditWorklog(shared.ID, &model.Work{Title: "Edit by Bob"})
+	assert.Nil(t, err)
+	_, _, err = svc.EditWorklog(shared.ID, &model.Work{Title: "Second edit by Bob"})
+	assert.Nil(t, err)
+	_, _, err = svc.SyncWorklogs("", "")
+	assert.Nil(t, err)
+
+	svc = NewWorklogService(alice)
+	_, _, err = svc.EditWorklog(shared.ID, &model.Work{Title: "Edit by Alice"})
+	assert.Nil(t, err)
+	_, code, err = svc.SyncWorklogs("", "")
+	assert.Equal(t, http.StatusConflict, code)
+	assert.EqualError(t, err, e.RepoSyncConflict+": "+shared.ID)
+	found, _, err = svc.GetWorklogsByID(&model.Work{}, shared.ID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Edit by Alice", found[0].Title)
+	assert.Equal(t, 4, found[0].Revision)
+}
+
+func TestSyncWorklogsStrategy(t *testing.T) {
+	svc := NewWorklogService(repository.NewGitRepo(filepath.Join(t.TempDir(), "git")))
+
+	_, code, err := svc.SyncWorklogs("", "keep-both")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.EqualError(t, err, e.SyncStrategy)
+
+	_, code, err = svc.SyncWorklogs("", SyncKeepLocal)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.EqualError(t, err, e.SyncStrategyGit)
+}
+
+func TestSyncWorklogsWithoutServer(t *testing.T) {
+	bolt := repository.NewBBoltRepo(filepath.Join(t.TempDir(), "worklog.db"))
+	svc := NewWorklogService(bolt)
+
+	_, code, err := svc.SyncWorklogs("", "")
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.EqualError(t, err, e.RepoSyncNoRemote)
+}
+
+// newSyncedBolt a bolt repository, which has been synced with the server
+func newSyncedBolt(t *testing.T, server repository.ReplicaRepository) repository.WorklogRepository {
+	bolt := repository.NewBBoltRepo(filepath.Join(t.TempDir(), "worklog.db"))
+	assert.Nil(t, bolt.Init())
+	_, err := syncReplica(bolt, bolt.(repository.SyncMarkRepository), server, "server", "")
+	assert.Nil(t, err)
+	return bolt
+}
+
+// syncBolt syncs the bolt repository with the server
+func syncBolt(bolt repository.WorklogRepository, server repository.ReplicaRepository, strategy string) (*model.SyncSummary, error) {
+	return syncReplica(bolt, bolt.(repository.SyncMarkRepository), server, "server", strategy)
+}
+
+func TestSyncReplica(t *testing.T) {
+	server := replica{repo: repository.NewMemoryRepo("")}
+	laptop := newSyncedBolt(t, server)
+	desktop := newSyncedBolt(t, server)
+	when := time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC)
+
+	// Worklogs created offline are shared
+	offline := model.NewWork("Offline", "On a plane", "Alice", 60, []string{"travel"}, when)
+	assert.Nil(t, laptop.Save(offline))
+	assert.Nil(t, desktop.Save(model.NewWork("At a desk", "", "Alice", 15, nil, when)))
+
+	summary, err := syncBolt(desktop, server, "")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.SyncSummary{Pushed: 1}, summary)
+	summary, err = syncBolt(laptop, server, "")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.SyncSummary{Pulled: 1, Pushed: 1}, summary)
+	summary, err = syncBolt(desktop, server, "")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.SyncSummary{Pulled: 1}, summary)
+
+	// Nothing is synced again
+	summary, err = syncBolt(laptop, server, "")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.SyncSummary{}, summary)
+
+	// Edits on one side are pulled by the other
+	edited := *offline
+	edited.Update(model.Work{Title: "Offline edit"})
+	assert.Nil(t, laptop.Save(&edited))
+	_, err = syncBolt(laptop, server, "")
+	assert.Nil(t, err)
+	summary, err = syncBolt(desktop, server, "")
+	assert.Nil(t, err)
+	assert.Equal(t, &model.SyncSummary{Pulled: 1}, summary)
+	found, err := desktop.GetByID(offline.ID, &model.Work{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Offline edit", found.Title)
+	assert.Equal(t, 2, found.Revision)
+
+	// Deleted worklogs are synced
+	deleted := *found
+	deleted.MarkDeleted()
+	assert.Nil(t, desktop.Save(&deleted))
+	_, err = syncBolt(desktop, server, "")
+	assert.Nil(t, err)
+	_, err = syncBolt(laptop, server, "")
+	assert.Nil(t, err)
+	found, err = laptop.GetByID(offline.ID, &model.Work{})
+	assert.Nil(t, err)
+	assert.True(t, found.Deleted)
+}
+
+func TestSyncReplicaConflicts(t *testing.T) {
+	var tests = []struct {
+		name        string
+		strategy    string
+		laptopFirst bool
+		expTitle    string
+		expRevision int
+	}{
+		{
+			name:        "Keep local",
+			strategy:    SyncKeepLocal,
+			expTitle:    "Laptop edit",
+			expRevision: 3,
+		}, {
+			name:        "Keep remote",
+			strategy:    SyncKeepRemote,
+			expTitle:    "Desktop edit",
+			expRevision: 2,
+		}, {
+			name:        "Keep newest, which is remote",
+			strategy:    SyncKeepNewest,
+			laptopFirst: true,
+			expTitle:    "Desktop edit",
+			expRevision: 2,
+		}, {
+			name:        "Keep newest, which is local",
+			strategy:    SyncKeepNewest,
+			expTitle:    "Laptop edit",
+			expRevision: 3,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			server := replica{repo: repository.NewMemoryRepo("")}
+			laptop := newSyncedBolt(t, server)
+			desktop := newSyncedBolt(t, server)
+			wl := model.NewWork("Shared", "", "Alice", 30, nil, time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC))
+			assert.Nil(t, laptop.Save(wl))
+			_, err := syncBolt(laptop, server, "")
+			assert.Nil(t, err)
+			_, err = syncBolt(desktop, server, "")
+			assert.Nil(t, err)
+
+			// Both edit the same revision while offline
+			laptopEdit, desktopEdit := *wl, *wl
+			laptopEdit.Update(model.Work{Title: "Laptop edit"})
+			desktopEdit.Update(model.Work{Title: "Desktop edit"})
+			if testItem.laptopFirst {
+				desktopEdit.CreatedAt = laptopEdit.CreatedAt.Add(time.Minute)
+			} else {
+				laptopEdit.CreatedAt = desktopEdit.CreatedAt.Add(time.Minute)
+			}
+			assert.Nil(t, laptop.Save(&laptopEdit))
+			assert.Nil(t, desktop.Save(&desktopEdit))
+			_, err = syncBolt(desktop, server, "")
+			assert.Nil(t, err)
+
+			// Without a strategy the conflict is left, and can be resolved later
+			summary, err := syncBolt(laptop, server, "")
+			assert.EqualError(t, err, e.RepoSyncConflict+": "+wl.ID)
+			assert.Equal(t, []string{wl.ID}, summary.Conflicts)
+			found, err := laptop.GetByID(wl.ID, &model.Work{})
+			assert.Nil(t, err)
+			assert.Equal(t, "Laptop edit", found.Title)
+
+			summary, err = syncBolt(laptop, server, testItem.strategy)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, summary.Resolved)
+			_, err = syncBolt(desktop, server, "")
+			assert.Nil(t, err)
+
+			for _, bolt := range []repository.WorklogRepository{laptop, desktop} {
+				found, err := bolt.GetByID(wl.ID, &model.Work{})
+				assert.Nil(t, err)
+				assert.Equal(t, testItem.expTitle, found.Title)
+				assert.Equal(t, testItem.expRevision, found.Revision)
+				revisions, err := bolt.GetRevisions(wl.ID)
+				assert.Nil(t, err)
+				assert.Len(t, revisions, testItem.expRevision)
+			}
+		})
+	}
+}
+
+func TestPushRevisions(t *testing.T) {
+	svc := NewWorklogService(repository.NewMemoryRepo(""))
+	wl := model.NewWork("Pushed", "", "Alice", 30, nil, time.Date(2026, time.October, 12, 9, 0, 0, 0, time.UTC))
+
+	conflicts, code, err := svc.PushRevisions([]*model.Work{wl})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, conflicts)
+
+	// Pushing the same revision again changes nothing
+	_, code, err = svc.PushRevisions([]*model.Work{wl})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+
+	changed := *wl
+	changed.Title = "Changed"
+	conflicts, code, err = svc.PushRevisions([]*model.Work{&changed})
+	assert.EqualError(t, err, e.RepoSyncConflict)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, []string{wl.ID}, conflicts)
+
+	_, code, err = svc.PushRevisions([]*model.Work{{Title: "No ID"}})
+	assert.EqualError(t, err, e.ImportRecordID)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	outside := *wl
+	outside.ID = "../../x"
+	_, code, err = svc.PushRevisions([]*model.Work{&outside})
+	assert.EqualError(t, err, e.ImportRecordIDInvalid)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	revs, code, err := svc.PullRevisions(map[string]int{})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, revs, 1)
+	assert.Equal(t, "Pushed", revs[0].Title)
+
+	revs, _, err = svc.PullRevisions(map[string]int{wl.ID: 1})
+	assert.Nil(t, err)
+	assert.Empty(t, revs)
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+)
+
+// timerRepo the repository is only able to store
+// timers if it also implements the TimerRepository
+func timerRepo() (repository.TimerRepository, error) {
+	timers, ok := repo.(repository.TimerRepository)
+	if !ok {
+		return nil, errors.New(e.RepoTimerUnsupported)
+	}
+	return timers, nil
+}
+
+func (*service) StartTimer(wl *model.Work) (int, error) {
+	timers, err := timerRepo()
+	if err != nil {
+		return http.StatusNotImplemented, err
+	}
+
+	running, err := timers.GetTimer()
+	if err != nil {
+		return http.StatusInternalServerError, err
+	} else if running != nil {
+		return http.StatusConflict, errors.New(e.TimerRunning)
+	}
+
+	wl.Title = helpers.Sanitize(strings.TrimSpace(wl.Title))
+	wl.Description = helpers.Sanitize(strings.TrimSpace(wl.Description))
+	wl.Author = helpers.Sanitize(strings.TrimSpace(wl.Author))
+	wl.Tags = helpers.DeduplicateString(wl.Tags)
+	if err := timers.SaveTimer(wl); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusCreated, nil
+}
+
+func (s *service) StopTimer() (*model.Work, int, error) {
+	timers, err := timerRepo()
+	if err != nil {
+		return nil, http.StatusNotImplemented, err
+	}
+
+	wl, err := timers.GetTimer()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	} else if wl == nil {
+		return nil, http.StatusNotFound, nil
+	}
+
+	// The timer is stopped first, so the work is never created twice
+	timer := *wl
+	if err := timers.DeleteTimer(); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	now, _ := helpers.GetStringAsDateTime(helpers.TimeFormat(time.Now()))
+	wl.Duration = elapsedMinutes(wl.When, now)
+	wl.CreatedAt = now
+	if code, err := s.CreateWorklog(wl); err != nil {
+		// Restarted, so the work can be stopped again
+		if restartErr := timers.SaveTimer(&timer); restartErr != nil {
+			helpers.LogError(fmt.Sprintf("unable to restart timer. %s", restartErr.Error()), "stop timer error")
+		}
+		return nil, code, err
+	}
+	return wl, http.StatusCreated, nil
+}
+
+func (*service) GetTimer() (*model.Work, int, error) {
+	timers, err := timerRepo()
+	if err != nil {
+		return nil, http.StatusNotImplemented, err
+	}
+
+	wl, err := timers.GetTimer()
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	} else if wl == nil {
+		return nil, http.StatusNotFound, nil
+	}
+	return wl, http.StatusOK, nil
+}
+
+// elapsedMinutes rounded up, so any running timer records some work
+func elapsedMinutes(start, end time.Time) int {
+	minutes := int(math.Ceil(end.Sub(start).Minutes()))
+	if minutes < 1 {
+		return 1
+	}
+	return minutes
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	e "github.com/PossibleLlama/worklog/errors"
+	"github.com/PossibleLlama/worklog/helpers"
+	"github.com/PossibleLlama/worklog/model"
+	"github.com/PossibleLlama/worklog/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// timerlessRepo a repository which is unable to store timers
+type timerlessRepo struct {
+	repository.WorklogRepository
+}
+
+func TestStartTimer(t *testing.T) {
+	randErr := errors.New(helpers.RandAlphabeticString(strLength))
+
+	var tests = []struct {
+		name     string
+		running  *model.Work
+		getErr   error
+		callSave bool
+		saveErr  error
+		expCode  int
+		expErr   error
+	}{
+		{
+			name:     "Starts timer",
+			callSave: true,
+			expCode:  http.StatusCreated,
+		}, {
+			name:    "Timer already running",
+			running: genWl(),
+			expCode: http.StatusConflict,
+			expErr:  errors.New(e.TimerRunning),
+		}, {
+			name:    "Error getting timer",
+			getErr:  randErr,
+			expCode: http.StatusInternalServerError,
+			expErr:  randErr,
+		}, {
+			name:     "Error saving timer",
+			callSave: true,
+			saveErr:  randErr,
+			expCode:  http.StatusInternalServerError,
+			expErr:   randErr,
+		},
+	}
+
+	for _, testItem := range tests {
+		wl := genWl()
+		wl.Tags = []string{"a", "a", "b"}
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetTimer").Return(testItem.running, testItem.getErr)
+		mockRepo.On("SaveTimer", wl).Return(testItem.saveErr)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			returnedCode, returnedErr := svc.StartTimer(wl)
+
+			assert.Equal(t, testItem.expErr, returnedErr)
+			assert.Equal(t, testItem.expCode, returnedCode)
+			if testItem.callSave {
+				mockRepo.AssertCalled(t, "SaveTimer", wl)
+				assert.Equal(t, []string{"a", "b"}, wl.Tags)
+			} else {
+				mockRepo.AssertNotCalled(t, "SaveTimer", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestStopTimer(t *testing.T) {
+	randErr := errors.New(helpers.RandAlphabeticString(strLength))
+
+	var tests = []struct {
+		name       string
+		running    bool
+		getErr     error
+		saveErr    error
+		deleteErr  error
+		restartErr error
+		expCode    int
+		expErr     error
+	}{
+		{
+			name:    "Stops timer",
+			running: true,
+			expCode: http.StatusCreated,
+		}, {
+			name:    "No timer running",
+			running: false,
+			expCode: http.StatusNotFound,
+		}, {
+			name:    "Error getting timer",
+			getErr:  randErr,
+			expCode: http.StatusInternalServerError,
+			expErr:  randErr,
+		}, {
+			name:    "Error saving work",
+			running: true,
+			saveErr: randErr,
+			expCode: http.StatusInternalServerError,
+			expErr:  randErr,
+		}, {
+			name:       "Error saving work and restarting timer",
+			running:    true,
+			saveErr:    randErr,
+			restartErr: errors.New(helpers.RandAlphabeticString(strLength)),
+			expCode:    http.StatusInternalServerError,
+			expErr:     randErr,
+		}, {
+			name:      "Error deleting timer",
+			running:   true,
+			deleteErr: randErr,
+			expCode:   http.StatusInternalServerError,
+			expErr:    randErr,
+		},
+	}
+
+	for _, testItem := range tests {
+		var running, started *model.Work
+		if testItem.running {
+			running = genWl()
+			running.When = time.Now().Add(time.Minute * -90)
+			copied := *running
+			started = &copied
+		}
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetTimer").Return(running, testItem.getErr)
+		mockRepo.On("Save", mock.Anything).Return(testItem.saveErr)
+		mockRepo.On("DeleteTimer").Return(testItem.deleteErr)
+		mockRepo.On("SaveTimer", mock.Anything).Return(testItem.restartErr)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			returnedWl, returnedCode, returnedErr := svc.StopTimer()
+
+			assert.Equal(t, testItem.expErr, returnedErr)
+			assert.Equal(t, testItem.expCode, returnedCode)
+			if testItem.expCode == http.StatusCreated {
+				assert.InDelta(t, 90, returnedWl.Duration, 1)
+				mockRepo.AssertCalled(t, "Save", running)
+				mockRepo.AssertCalled(t, "DeleteTimer")
+			}
+			if testItem.saveErr != nil {
+				// Stopped before saving, then restarted as it was
+				mockRepo.AssertCalled(t, "DeleteTimer")
+				mockRepo.AssertCalled(t, "SaveTimer", started)
+			} else {
+				mockRepo.AssertNotCalled(t, "SaveTimer", mock.Anything)
+			}
+			if testItem.deleteErr != nil {
+				mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestTimerUnsupported(t *testing.T) {
+	svc := NewWorklogService(&timerlessRepo{})
+
+	startCode, startErr := svc.StartTimer(genWl())
+	_, stopCode, stopErr := svc.StopTimer()
+	_, getCode, getErr := svc.GetTimer()
+
+	for _, code := range []int{startCode, stopCode, getCode} {
+		assert.Equal(t, http.StatusNotImplemented, code)
+	}
+	for _, err := range []error{startErr, stopErr, getErr} {
+		assert.EqualError(t, err, e.RepoTimerUnsupported)
+	}
+}
+
+func TestElapsedMinutes(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		name string
+		end  time.Time
+		exp  int
+	}{
+		{
+			name: "Whole minutes",
+			end:  start.Add(time.Minute * 30),
+			exp:  30,
+		}, {
+			name: "Partial minutes round up",
+			end:  start.Add(time.Minute*30 + time.Second),
+			exp:  31,
+		}, {
+			name: "Less than a minute is a minute",
+			end:  start,
+			exp:  1,
+		},
+	}
+
+	for _, testItem := range tests {
+		t.Run(testItem.name, func(t *testing.T) {
+			assert.Equal(t, testItem.exp, elapsedMinutes(start, testItem.end))
+		})
+	}
+}
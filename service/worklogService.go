@@ -1,10 +1,7 @@
 package service
 
 import (
-	"encoding/json"
-	"fmt"
 	"net/http"
-	"os"
 	"sort"
 	"strings"
 	"time"
@@ -79,6 +76,60 @@ func (s *service) EditWorklog(id string, newWl *model.Work) (*model.Work, int, e
 	return wl, http.StatusOK, nil
 }
 
+func (*service) DeleteWorklog(id string, purge bool) (int, error) {
+	wl, err := repo.GetByID(id, &model.Work{})
+	if err != nil {
+		if err.Error() == e.RepoGetSingleFileAmbiguous {
+			return http.StatusNotFound, nil
+		}
+		return http.StatusInternalServerError, err
+	}
+	// A purge can also remove worklogs which have
+	// already been soft deleted
+	if wl == nil || (wl.Deleted && !purge) {
+		return http.StatusNotFound, nil
+	}
+
+	if purge {
+		if err := repo.Delete(wl.ID); err != nil {
+			return http.StatusInternalServerError, err
+		}
+		return http.StatusNoContent, nil
+	}
+
+	wl.MarkDeleted()
+	if err := repo.Save(wl); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	return http.StatusNoContent, nil
+}
+
+func (s *service) RevertWorklog(id string, revision int) (*model.Work, int, error) {
+	revisions, code, err := s.GetWorklogRevisions(id)
+	if err != nil || code == http.StatusNotFound {
+		return nil, code, err
+	}
+
+	var old *model.Work
+	for _, wl := range revisions {
+		if wl.Revision == revision {
+			old = wl
+		}
+	}
+	if old == nil {
+		return nil, http.StatusNotFound, nil
+	}
+
+	// Revisions are sorted, so the last is the latest
+	wl := *revisions[len(revisions)-1]
+	wl.RevertTo(*old)
+
+	if err := repo.Save(&wl); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return &wl, http.StatusOK, nil
+}
+
 func (*service) GetWorklogsBetween(start, end time.Time, filter *model.Work) ([]*model.Work, int, error) {
 	var worklogs model.WorkList
 	var err error
@@ -110,7 +161,7 @@ func (*service) GetWorklogsByID(filter *model.Work, ids ...string) ([]*model.Wor
 			}
 			return worklogs, http.StatusInternalServerError, err
 		}
-		if wl != nil {
+		if wl != nil && !wl.Deleted {
 			worklogs = append(worklogs, wl)
 		}
 	}
@@ -121,23 +172,19 @@ func (*service) GetWorklogsByID(filter *model.Work, ids ...string) ([]*model.Wor
 	return worklogs, http.StatusOK, nil
 }
 
-func (*service) ExportTo(path string) (int, error) {
-	all, err := repo.GetAll()
-	if err != nil {
-		return http.StatusInternalServerError, err
-	}
-
-	data, err := json.Marshal(all)
+func (*service) GetWorklogRevisions(id string) ([]*model.Work, int, error) {
+	revisions, err := repo.GetRevisions(id)
 	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("error encoding worklogs. %s", err.Error())
+		if err.Error() == e.RepoGetSingleFileAmbiguous {
+			return []*model.Work{}, http.StatusNotFound, nil
+		}
+		return []*model.Work{}, http.StatusInternalServerError, err
 	}
-	// #nosec G306 -- Not concerned that others on machine can access the exported values
-	// if the user wants to update permissions later, they can
-	err = os.WriteFile(path, data, 0644)
-	if err != nil {
-		return http.StatusInternalServerError, fmt.Errorf("error saving file. %s", err.Error())
+	if len(revisions) == 0 {
+		return revisions, http.StatusNotFound, nil
 	}
-
-	helpers.LogDebug("Saved export file", "save export successful - json")
-	return http.StatusOK, nil
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[i].Revision < revisions[j].Revision
+	})
+	return revisions, http.StatusOK, nil
 }
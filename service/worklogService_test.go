@@ -7,12 +7,14 @@ import (
 	"testing"
 	"time"
 
+	e "github.com/PossibleLlama/worklog/errors"
 	"github.com/PossibleLlama/worklog/helpers"
 	"github.com/PossibleLlama/worklog/model"
 	"github.com/PossibleLlama/worklog/repository"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var src = rand.New(rand.NewSource(time.Now().UnixNano()))
@@ -497,3 +499,247 @@ func TestGetWorklogByID(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteWorklog(t *testing.T) {
+	id := helpers.RandHexAlphaNumericString(strLength)
+	deletedWl := genWl()
+	deletedWl.Deleted = true
+
+	var tests = []struct {
+		name      string
+		purge     bool
+		getWl     *model.Work
+		getErr    error
+		callSave  bool
+		callPurge bool
+		repoErr   error
+		expCode   int
+		expErr    error
+	}{
+		{
+			name:     "Soft delete saves a deleted revision",
+			purge:    false,
+			getWl:    genWl(),
+			callSave: true,
+			expCode:  http.StatusNoContent,
+		}, {
+			name:      "Purge removes all revisions",
+			purge:     true,
+			getWl:     genWl(),
+			callPurge: true,
+			expCode:   http.StatusNoContent,
+		}, {
+			name:      "Purge removes already deleted",
+			purge:     true,
+			getWl:     deletedWl,
+			callPurge: true,
+			expCode:   http.StatusNoContent,
+		}, {
+			name:    "Already deleted is not found",
+			purge:   false,
+			getWl:   deletedWl,
+			expCode: http.StatusNotFound,
+		}, {
+			name:    "No found wl's",
+			purge:   false,
+			getWl:   nil,
+			expCode: http.StatusNotFound,
+		}, {
+			name:    "Error from get",
+			purge:   false,
+			getWl:   nil,
+			getErr:  errors.New(id),
+			expCode: http.StatusInternalServerError,
+			expErr:  errors.New(id),
+		}, {
+			name:     "Error from save",
+			purge:    false,
+			getWl:    genWl(),
+			callSave: true,
+			repoErr:  errors.New(id),
+			expCode:  http.StatusInternalServerError,
+			expErr:   errors.New(id),
+		}, {
+			name:      "Error from purge",
+			purge:     true,
+			getWl:     genWl(),
+			callPurge: true,
+			repoErr:   errors.New(id),
+			expCode:   http.StatusInternalServerError,
+			expErr:    errors.New(id),
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetByID", id, &model.Work{}).
+			Return(testItem.getWl, testItem.getErr)
+		var expRevision int
+		if testItem.getWl != nil {
+			expRevision = testItem.getWl.Revision + 1
+			mockRepo.On("Save", testItem.getWl).Return(testItem.repoErr)
+			mockRepo.On("Delete", testItem.getWl.ID).Return(testItem.repoErr)
+		}
+
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			returnedCode, returnedErr := svc.DeleteWorklog(id, testItem.purge)
+
+			assert.Equal(t, testItem.expErr, returnedErr)
+			assert.Equal(t, testItem.expCode, returnedCode)
+			mockRepo.AssertCalled(t, "GetByID", id, &model.Work{})
+			if testItem.callSave {
+				mockRepo.AssertCalled(t, "Save", testItem.getWl)
+				assert.True(t, testItem.getWl.Deleted)
+				assert.Equal(t, expRevision, testItem.getWl.Revision)
+			} else {
+				mockRepo.AssertNotCalled(t, "Save", testItem.getWl)
+			}
+			if testItem.callPurge {
+				mockRepo.AssertCalled(t, "Delete", testItem.getWl.ID)
+			} else if testItem.getWl != nil {
+				mockRepo.AssertNotCalled(t, "Delete", testItem.getWl.ID)
+			}
+		})
+	}
+}
+
+func TestGetWorklogRevisions(t *testing.T) {
+	id := helpers.RandHexAlphaNumericString(strLength)
+	rev1Wl := genWl()
+	rev2Wl := &model.Work{
+		ID:       rev1Wl.ID,
+		Revision: rev1Wl.Revision + 1,
+		Title:    rev1Wl.Title,
+	}
+
+	var tests = []struct {
+		name   string
+		retWl  []*model.Work
+		retErr error
+		expWl  []*model.Work
+		exCode int
+		expErr error
+	}{
+		{
+			name:   "Sorted by revision",
+			retWl:  []*model.Work{rev2Wl, rev1Wl},
+			expWl:  []*model.Work{rev1Wl, rev2Wl},
+			exCode: http.StatusOK,
+		}, {
+			name:   "None found",
+			retWl:  []*model.Work{},
+			expWl:  []*model.Work{},
+			exCode: http.StatusNotFound,
+		}, {
+			name:   "Ambiguous ID is not found",
+			retWl:  nil,
+			retErr: errors.New(e.RepoGetSingleFileAmbiguous),
+			expWl:  []*model.Work{},
+			exCode: http.StatusNotFound,
+		}, {
+			name:   "Errored",
+			retWl:  nil,
+			retErr: errors.New(id),
+			expWl:  []*model.Work{},
+			exCode: http.StatusInternalServerError,
+			expErr: errors.New(id),
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetRevisions", id).Return(testItem.retWl, testItem.retErr)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			returnedWls, returnedCode, returnedErr := svc.GetWorklogRevisions(id)
+
+			assert.Equal(t, testItem.expErr, returnedErr)
+			assert.Equal(t, testItem.exCode, returnedCode)
+			assert.Equal(t, testItem.expWl, returnedWls)
+			mockRepo.AssertCalled(t, "GetRevisions", id)
+		})
+	}
+}
+
+func TestRevertWorklog(t *testing.T) {
+	id := helpers.RandHexAlphaNumericString(strLength)
+	rev1Wl := genWl()
+	rev2Wl := genWl()
+	rev2Wl.ID = rev1Wl.ID
+	rev2Wl.Revision = 2
+
+	var tests = []struct {
+		name     string
+		revision int
+		retWl    []*model.Work
+		retErr   error
+		callSave bool
+		saveErr  error
+		exCode   int
+		expErr   error
+	}{
+		{
+			name:     "Reverts to earlier revision",
+			revision: 1,
+			retWl:    []*model.Work{rev1Wl, rev2Wl},
+			callSave: true,
+			exCode:   http.StatusOK,
+		}, {
+			name:     "Revision not found",
+			revision: 3,
+			retWl:    []*model.Work{rev1Wl, rev2Wl},
+			exCode:   http.StatusNotFound,
+		}, {
+			name:     "ID not found",
+			revision: 1,
+			retWl:    []*model.Work{},
+			exCode:   http.StatusNotFound,
+		}, {
+			name:     "Error from get",
+			revision: 1,
+			retWl:    nil,
+			retErr:   errors.New(id),
+			exCode:   http.StatusInternalServerError,
+			expErr:   errors.New(id),
+		}, {
+			name:     "Error from save",
+			revision: 1,
+			retWl:    []*model.Work{rev1Wl, rev2Wl},
+			callSave: true,
+			saveErr:  errors.New(id),
+			exCode:   http.StatusInternalServerError,
+			expErr:   errors.New(id),
+		},
+	}
+
+	for _, testItem := range tests {
+		mockRepo := new(repository.MockRepo)
+		mockRepo.On("GetRevisions", id).Return(testItem.retWl, testItem.retErr)
+		mockRepo.On("Save", mock.Anything).Return(testItem.saveErr)
+		svc := NewWorklogService(mockRepo)
+
+		t.Run(testItem.name, func(t *testing.T) {
+			returnedWl, returnedCode, returnedErr := svc.RevertWorklog(id, testItem.revision)
+
+			assert.Equal(t, testItem.expErr, returnedErr)
+			assert.Equal(t, testItem.exCode, returnedCode)
+			if testItem.callSave {
+				mockRepo.AssertNumberOfCalls(t, "Save", 1)
+			} else {
+				mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+			}
+			if returnedCode == http.StatusOK {
+				assert.Equal(t, rev1Wl.ID, returnedWl.ID)
+				assert.Equal(t, 3, returnedWl.Revision)
+				assert.Equal(t, rev1Wl.Title, returnedWl.Title)
+				assert.Equal(t, rev1Wl.Tags, returnedWl.Tags)
+				assert.Equal(t, 2, rev2Wl.Revision, "Stored revisions are unchanged")
+			} else {
+				assert.Nil(t, returnedWl)
+			}
+		})
+	}
+}